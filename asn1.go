@@ -0,0 +1,255 @@
+package gimel
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+)
+
+// ASN1RealEncoding is the encoding of the content octets of an ASN.1 REAL
+type ASN1RealEncoding byte
+
+const (
+	ASN1Decimal ASN1RealEncoding = iota // base 10 using the ISO 6093 NR3 form
+	ASN1Binary                          // base 2 with a binary mantissa and exponent
+)
+
+const (
+	// asn1TagReal is the universal tag number of REAL
+	asn1TagReal = 0x09
+
+	// special real values
+	asn1PlusInf  = 0x40
+	asn1MinusInf = 0x41
+	asn1NaN      = 0x42
+	asn1NegZero  = 0x43
+
+	// asn1MaxExp is the largest power of two allowed when decoding a binary REAL
+	asn1MaxExp = 1 << 20
+)
+
+var (
+	errInvalidASN1Real = errors.New("gimel: invalid ASN.1 REAL")
+	errASN1RealRange   = errors.New("gimel: number is out of range for ASN.1 REAL")
+	errASN1RealInexact = errors.New("gimel: number is not exactly representable as a binary ASN.1 REAL")
+)
+
+// EncodeASN1Real returns the DER encoding of g as an ASN.1 REAL including the tag and length
+//
+// Zero has no content octets, negative zero, the infinities and NaN use the
+// special octets. The decimal encoding is the NR3 form without trailing zeros
+// in the mantissa, like "314.E-2". The binary encoding uses base 2 with an odd
+// mantissa and is only possible if g is a finite sum of powers of two.
+func EncodeASN1Real(g Gimel, enc ASN1RealEncoding) ([]byte, error) {
+	if err := checkPrec("asn1real", g); err != nil {
+		return nil, err
+	}
+	var content []byte
+	switch {
+	case g.IsNaN():
+		content = []byte{asn1NaN}
+	case g.IsInf() && g.neg:
+		content = []byte{asn1MinusInf}
+	case g.IsInf():
+		content = []byte{asn1PlusInf}
+	case g.digits.Sign() == 0 && g.neg:
+		content = []byte{asn1NegZero}
+	case g.digits.Sign() == 0:
+	case enc == ASN1Decimal:
+		content = g.asn1Decimal()
+	case enc == ASN1Binary:
+		var err error
+		content, err = g.asn1Binary()
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errInvalidASN1Real
+	}
+
+	b := []byte{asn1TagReal}
+	if len(content) < 0x80 {
+		b = append(b, byte(len(content)))
+	} else {
+		l := big.NewInt(int64(len(content))).Bytes()
+		b = append(b, 0x80|byte(len(l)))
+		b = append(b, l...)
+	}
+	return append(b, content...), nil
+}
+
+// asn1Decimal is an internal function to get the NR3 content octets of a finite non-zero number
+func (g Gimel) asn1Decimal() []byte {
+	ds := g.digits.String()
+	m := strings.TrimRight(ds, "0")
+	e := new(big.Int).Add(g.unitExp(), big.NewInt(int64(len(ds)-len(m))))
+
+	var b strings.Builder
+	b.WriteByte(0x03)
+	if g.neg {
+		b.WriteByte('-')
+	}
+	b.WriteString(m)
+	b.WriteString(".E")
+	if e.Sign() == 0 {
+		b.WriteByte('+')
+	}
+	b.WriteString(e.String())
+	return []byte(b.String())
+}
+
+// asn1Binary is an internal function to get the base 2 content octets of a finite non-zero number
+func (g Gimel) asn1Binary() ([]byte, error) {
+	n, e, err := g.dyadic(asn1MaxExp, errASN1RealRange, errASN1RealInexact)
+	if err != nil {
+		return nil, err
+	}
+
+	// the exponent is the fewest two's complement octets
+	eb, err := fromCoef(e.Sign() == -1, new(big.Int).Abs(e), zeroValue, nil).ToUnscaled(0, 0)
+	if err != nil {
+		return nil, err
+	}
+	first := byte(0x80)
+	if g.neg {
+		first |= 0x40
+	}
+	b := []byte{first}
+	switch {
+	case len(eb) <= 3:
+		b[0] |= byte(len(eb) - 1)
+	case len(eb) <= 0xff:
+		b[0] |= 0x03
+		b = append(b, byte(len(eb)))
+	default:
+		return nil, errASN1RealRange
+	}
+	b = append(b, eb...)
+	return append(b, n.Bytes()...), nil
+}
+
+// DecodeASN1Real returns the Gimel number from the BER or DER encoding of an ASN.1 REAL including the tag and length
+//
+// The decimal NR1, NR2 and NR3 forms and the binary bases 2, 8 and 16 with a
+// scale factor are accepted. If prec is nil then the precision is the number of
+// digits needed to represent the value exactly.
+func DecodeASN1Real(b []byte, prec *big.Int) (Gimel, error) {
+	if len(b) < 2 || b[0] != asn1TagReal {
+		return Gimel{}, errInvalidASN1Real
+	}
+	l, b := int(b[1]), b[2:]
+	if l&0x80 != 0 {
+		n := l &^ 0x80
+		if n == 0 || n > 4 || len(b) < n {
+			return Gimel{}, errInvalidASN1Real
+		}
+		l = int(new(big.Int).SetBytes(b[:n]).Int64())
+		b = b[n:]
+	}
+	if l != len(b) {
+		return Gimel{}, errInvalidASN1Real
+	}
+
+	if len(b) == 0 {
+		return fromCoef(false, new(big.Int), zeroValue, prec), nil
+	}
+	switch {
+	case b[0]&0x80 != 0:
+		return decodeASN1Binary(b, prec)
+	case b[0]&0x40 != 0:
+		if len(b) != 1 {
+			return Gimel{}, errInvalidASN1Real
+		}
+		switch b[0] {
+		case asn1PlusInf, asn1MinusInf:
+			return Inf(b[0] == asn1MinusInf, specialPrec(prec)), nil
+		case asn1NaN:
+			return NaN(specialPrec(prec)), nil
+		case asn1NegZero:
+			return fromCoef(true, new(big.Int), zeroValue, prec), nil
+		}
+		return Gimel{}, errInvalidASN1Real
+	}
+	return decodeASN1Decimal(b, prec)
+}
+
+// decodeASN1Binary is an internal function to decode the binary content octets of an ASN.1 REAL
+func decodeASN1Binary(b []byte, prec *big.Int) (Gimel, error) {
+	first := b[0]
+	var k int64
+	switch first >> 4 & 0x03 {
+	case 0:
+		k = 1
+	case 1:
+		k = 3
+	case 2:
+		k = 4
+	default:
+		return Gimel{}, errInvalidASN1Real
+	}
+	b = b[1:]
+	n := int(first&0x03) + 1
+	if n == 4 {
+		if len(b) == 0 {
+			return Gimel{}, errInvalidASN1Real
+		}
+		n, b = int(b[0]), b[1:]
+	}
+	if n == 0 || len(b) <= n {
+		return Gimel{}, errInvalidASN1Real
+	}
+
+	// the value is N*2^F*B^E so the power of two is F+E*log2(B)
+	e := FromUnscaled(b[:n], 0).BigInt()
+	e.Mul(e, big.NewInt(k))
+	e.Add(e, big.NewInt(int64(first>>2&0x03)))
+	if e.CmpAbs(big.NewInt(asn1MaxExp)) == 1 {
+		return Gimel{}, errASN1RealRange
+	}
+	return fromDyadic(first&0x40 != 0, new(big.Int).SetBytes(b[n:]), e.Int64(), prec), nil
+}
+
+// decodeASN1Decimal is an internal function to decode the ISO 6093 content octets of an ASN.1 REAL
+func decodeASN1Decimal(b []byte, prec *big.Int) (Gimel, error) {
+	form := b[0]
+	if form < 0x01 || form > 0x03 {
+		return Gimel{}, errInvalidASN1Real
+	}
+	s := strings.TrimLeft(string(b[1:]), " ")
+
+	var neg bool
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		neg, s = s[0] == '-', s[1:]
+	}
+	m, exp := s, ""
+	if form == 0x03 {
+		i := strings.IndexAny(s, "Ee")
+		if i == -1 {
+			return Gimel{}, errInvalidASN1Real
+		}
+		m, exp = s[:i], s[i+1:]
+	}
+
+	// NR1 has no decimal mark and NR2 has a decimal mark
+	e := new(big.Int)
+	mark := strings.IndexAny(m, ".,")
+	switch {
+	case form == 0x01 && mark != -1, form == 0x02 && mark == -1:
+		return Gimel{}, errInvalidASN1Real
+	case mark != -1:
+		e.SetInt64(int64(mark - len(m) + 1))
+		m = m[:mark] + m[mark+1:]
+	}
+	if m == "" || strings.Trim(m, "0123456789") != "" {
+		return Gimel{}, errInvalidASN1Real
+	}
+	if form == 0x03 {
+		x, ok := new(big.Int).SetString(exp, 10)
+		if !ok {
+			return Gimel{}, errInvalidASN1Real
+		}
+		e.Add(e, x)
+	}
+	c, _ := new(big.Int).SetString(m, 10)
+	return fromCoef(neg, c, e, prec), nil
+}
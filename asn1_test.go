@@ -0,0 +1,98 @@
+package gimel
+
+import (
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+)
+
+func TestEncodeASN1Real(t *testing.T) {
+	for _, i := range []struct {
+		g   Gimel
+		enc ASN1RealEncoding
+		b   string
+	}{
+		{gen(false, 314, 0), ASN1Decimal, "0908" + hex.EncodeToString([]byte("\x03314.E-2"))},
+		{gen(true, 12, 1), ASN1Decimal, "0908" + hex.EncodeToString([]byte("\x03-12.E+0"))},
+		{gen(false, 12, 3), ASN1Decimal, "0906" + hex.EncodeToString([]byte("\x0312.E2"))},
+		{gen(false, 0, 0), ASN1Decimal, "0900"},
+		{gen(true, 0, 0), ASN1Binary, "090143"},
+		{Inf(false, prec), ASN1Decimal, "090140"},
+		{Inf(true, prec), ASN1Binary, "090141"},
+		{NaN(prec), ASN1Decimal, "090142"},
+		{gen(false, 1, 0), ASN1Binary, "0903800001"},
+		{gen(false, 5, 0), ASN1Binary, "0903800005"},
+		{gen(true, 75, 0), ASN1Binary, "0903c0ff0f"},
+		{gen(false, 12, 3), ASN1Binary, "090380044b"},
+	} {
+		b, err := EncodeASN1Real(i.g, i.enc)
+		assert.NoError(t, err)
+		assert.Equal(t, i.b, hex.EncodeToString(b), i.g.String())
+
+		a, err := DecodeASN1Real(b, prec)
+		assert.NoError(t, err)
+		if i.g.IsNaN() {
+			assert.True(t, a.IsNaN())
+		} else {
+			assert.True(t, i.g.Eq(a), i.g.String())
+			assert.Equal(t, i.g.IsNeg(), a.IsNeg(), i.g.String())
+		}
+	}
+
+	_, err := EncodeASN1Real(gen(false, 1, -1), ASN1Binary)
+	assert.Equal(t, errASN1RealInexact, err)
+	_, err = EncodeASN1Real(gen(false, 1, 1000000000), ASN1Binary)
+	assert.Equal(t, errASN1RealRange, err)
+	_, err = EncodeASN1Real(Gimel{}, ASN1Decimal)
+	assert.Error(t, err)
+}
+
+func TestDecodeASN1Real(t *testing.T) {
+	realTLV := func(content string) []byte {
+		return append([]byte{asn1TagReal, byte(len(content))}, content...)
+	}
+	for _, i := range []struct {
+		b []byte
+		s string
+	}{
+		{realTLV("\x01  -42"), "-4.2e1"},
+		{realTLV("\x02+3,25"), "3.25"},
+		{realTLV("\x02.5"), "0.5"},
+		{realTLV("\x031.5e-3"), "0.0015"},
+		{realTLV("\x0325E+1"), "2.50e2"},
+		{realTLV("\x80\x01\x03"), "6"},
+		{realTLV("\xc0\xff\x01"), "-0.5"},
+		{realTLV("\x90\x01\x01"), "8"},
+		{realTLV("\xa0\xff\x01"), "0.0625"},
+		{realTLV("\x84\x00\x01"), "2"},
+		{realTLV("\x83\x01\x02\x03"), "1.2e1"},
+	} {
+		g, err := DecodeASN1Real(i.b, nil)
+		assert.NoError(t, err)
+		a, err := Parse(i.s)
+		assert.NoError(t, err)
+		assert.True(t, a.Eq(g), i.s)
+	}
+
+	// long form length
+	g, err := DecodeASN1Real([]byte{asn1TagReal, 0x81, 0x03, 0x80, 0x00, 0x07}, nil)
+	assert.NoError(t, err)
+	assert.True(t, g.Eq(gen(false, 7, 0)))
+
+	for _, b := range [][]byte{
+		{}, {0x04, 0x00}, {asn1TagReal, 0x02, 0x40}, realTLV("\x44"), realTLV("\x04"),
+		realTLV("\x011.5"), realTLV("\x0215"), realTLV("\x0315"), realTLV("\x031.5E"), realTLV("\x03E5"),
+		realTLV("\x801"), realTLV("\xb0\x00\x01"), realTLV("\x80\x00"), realTLV("\x83\x04\x01\x01"),
+		realTLV("\x01\x0012"), realTLV("\x021..5"),
+	} {
+		_, err := DecodeASN1Real(b, nil)
+		assert.Error(t, err, hex.EncodeToString(b))
+	}
+	_, err = DecodeASN1Real(realTLV("\x82\x7f\xff\xff\x01"), nil)
+	assert.Error(t, err)
+
+	g, err = DecodeASN1Real(realTLV("\x80\x01\x03"), big.NewInt(3))
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(3), g.prec)
+}
@@ -0,0 +1,119 @@
+package gimel
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// binaryVersion is the version of the binary encoding written by MarshalBinary
+const binaryVersion = 1
+
+var (
+	errInvalidBinary = errors.New("gimel: invalid binary encoding")
+	errBinaryRange   = errors.New("gimel: number is out of range for the binary encoding")
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler
+//
+// Version 1 of the encoding is:
+//
+//	version  byte
+//	flags    byte, bit 0 is the sign, bits 1-2 are the form and bits 3-5 are the rounding mode
+//	exp      varint
+//	prec     uvarint
+//	digits   uvarint length followed by the big-endian bytes of the digits or NaN payload
+func (g Gimel) MarshalBinary() ([]byte, error) {
+	return g.appendBinary(nil)
+}
+
+// appendBinary is an internal function to append the binary encoding of g to b
+func (g Gimel) appendBinary(b []byte) ([]byte, error) {
+	if err := checkPrec("marshal", g); err != nil {
+		return nil, err
+	}
+	if !g.exp.IsInt64() || !g.prec.IsUint64() || g.prec.Uint64() > maxPrec {
+		return nil, errBinaryRange
+	}
+	flags := byte(g.form)<<1 | byte(g.mode)<<3
+	if g.neg {
+		flags |= 1
+	}
+	d := g.digits.Bytes()
+	b = append(b, binaryVersion, flags)
+	b = binary.AppendVarint(b, g.exp.Int64())
+	b = binary.AppendUvarint(b, g.prec.Uint64())
+	b = binary.AppendUvarint(b, uint64(len(d)))
+	return append(b, d...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (g *Gimel) UnmarshalBinary(data []byte) error {
+	a, n, err := readBinary(data)
+	if err != nil {
+		return err
+	}
+	if n != len(data) {
+		return errInvalidBinary
+	}
+	*g = a
+	return nil
+}
+
+// readBinary is an internal function to read a binary encoded Gimel number from the start of data
+// the number of bytes read is also returned
+func readBinary(data []byte) (Gimel, int, error) {
+	if len(data) < 2 {
+		return Gimel{}, 0, errInvalidBinary
+	}
+	if data[0] != binaryVersion {
+		return Gimel{}, 0, fmt.Errorf("gimel: unsupported binary encoding version %d", data[0])
+	}
+	flags := data[1]
+	f, mode := form(flags>>1&3), RoundingMode(flags>>3&7)
+	if flags>>6 != 0 {
+		return Gimel{}, 0, errInvalidBinary
+	}
+	n := 2
+
+	exp, i := binary.Varint(data[n:])
+	if i <= 0 {
+		return Gimel{}, 0, errInvalidBinary
+	}
+	n += i
+	prec, i := binary.Uvarint(data[n:])
+	if i <= 0 || prec == 0 {
+		return Gimel{}, 0, errInvalidBinary
+	}
+	if prec > maxPrec {
+		return Gimel{}, 0, errBinaryRange
+	}
+	n += i
+	l, i := binary.Uvarint(data[n:])
+	if i <= 0 || l > uint64(len(data)-n-i) {
+		return Gimel{}, 0, errInvalidBinary
+	}
+	n += i
+
+	digits := new(big.Int).SetBytes(data[n : n+int(l)])
+	n += int(l)
+	g := g2(flags&1 != 0, digits, big.NewInt(exp), new(big.Int).SetUint64(prec), mode)
+	g.form = f
+
+	// finite numbers must be normalised to the precision
+	if f == finite && digits.Sign() != 0 {
+		var min big.Int
+		min.Quo(g.p10p, tenValue)
+		if digits.Cmp(&min) == -1 || digits.Cmp(g.p10p) != -1 {
+			return Gimel{}, 0, errInvalidBinary
+		}
+	}
+	return g, n, nil
+}
+
+// GobEncode implements gob.GobEncoder using the binary encoding
+func (g Gimel) GobEncode() ([]byte, error) { return g.MarshalBinary() }
+
+// GobDecode implements gob.GobDecoder using the binary encoding
+func (g *Gimel) GobDecode(data []byte) error { return g.UnmarshalBinary(data) }
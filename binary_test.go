@@ -0,0 +1,75 @@
+package gimel
+
+import (
+	"bytes"
+	"encoding/gob"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+)
+
+func TestGimel_MarshalBinary(t *testing.T) {
+	b, err := gen(true, 123, -6).Rounding(RoundUp).MarshalBinary()
+	assert.NoError(t, err)
+	// 12300 is 0x300c
+	assert.Equal(t, []byte{1, 1 | 4<<3, 11, 5, 2, 0x30, 0x0c}, b)
+
+	for _, g := range []Gimel{
+		gen(false, 123, 6),
+		gen(true, 0, 3),
+		Inf(true, prec),
+		special(snan, false, big.NewInt(300), prec, RoundFloor),
+		Pi,
+		G(false, big.NewInt(1), big.NewInt(-1e15), big.NewInt(1000)),
+	} {
+		b, err := g.MarshalBinary()
+		assert.NoError(t, err)
+		var a Gimel
+		assert.NoError(t, a.UnmarshalBinary(b))
+		assert.True(t, identical(g, a), g.String())
+	}
+
+	_, err = Gimel{}.MarshalBinary()
+	assert.Error(t, err)
+	_, err = G(false, big.NewInt(1), new(big.Int).Lsh(oneValue, 64), prec).MarshalBinary()
+	assert.Error(t, err)
+}
+
+func TestGimel_UnmarshalBinary(t *testing.T) {
+	var a Gimel
+	for _, b := range [][]byte{
+		nil,
+		{1},
+		{1, 0},
+		{1, 0, 0},
+		{1, 0, 0, 0, 0},
+		{1, 0, 0, 5, 3, 0x30},
+		{1, 0, 0, 5, 2, 0x30, 0x0c, 0},
+		{1, 0x40, 0, 5, 2, 0x30, 0x0c},
+		// digits must have exactly prec digits
+		{1, 0, 0, 5, 1, 0x0c},
+		{1, 0, 0, 1, 1, 0x0c},
+	} {
+		assert.Error(t, a.UnmarshalBinary(b), b)
+	}
+	assert.EqualError(t, a.UnmarshalBinary([]byte{2, 0, 0, 5, 0}), "gimel: unsupported binary encoding version 2")
+	// a huge precision is rejected before building its power of ten
+	assert.Equal(t, errBinaryRange, a.UnmarshalBinary([]byte{1, 0, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01, 0}))
+	assert.NoError(t, a.UnmarshalBinary([]byte{1, 0, 0, 5, 0}))
+	assert.True(t, a.IsZero())
+}
+
+func TestGimel_Gob(t *testing.T) {
+	type constants struct {
+		Pi, Euler Gimel
+		Ln2       *Gimel
+	}
+	var buf bytes.Buffer
+	assert.NoError(t, gob.NewEncoder(&buf).Encode(constants{Pi, Euler, &Ln2}))
+
+	var c constants
+	assert.NoError(t, gob.NewDecoder(&buf).Decode(&c))
+	assert.True(t, identical(Pi, c.Pi))
+	assert.True(t, identical(Euler, c.Euler))
+	assert.True(t, identical(Ln2, *c.Ln2))
+}
@@ -0,0 +1,34 @@
+package gimel
+
+import "errors"
+
+var errInvalidBSONDecimal128 = errors.New("gimel: invalid BSON Decimal128")
+
+// EncodeBSONDecimal128 returns g as a BSON Decimal128
+//
+// BSON uses the IEEE 754 decimal128 BID encoding stored in little-endian byte
+// order, the exponent of the last digit is between -6176 and 6111. The number
+// is rounded and clamped the same way as EncodeIEEE754.
+func EncodeBSONDecimal128(g Gimel) ([16]byte, error) {
+	var r [16]byte
+	b, err := EncodeIEEE754(g, Decimal128, BID)
+	if err != nil {
+		return r, err
+	}
+	for i := range b {
+		r[15-i] = b[i]
+	}
+	return r, nil
+}
+
+// DecodeBSONDecimal128 returns the Gimel number from a BSON Decimal128 with a precision of 34 digits
+func DecodeBSONDecimal128(b []byte) (Gimel, error) {
+	if len(b) != 16 {
+		return Gimel{}, errInvalidBSONDecimal128
+	}
+	var r [16]byte
+	for i := range b {
+		r[15-i] = b[i]
+	}
+	return DecodeIEEE754(r[:], Decimal128, BID)
+}
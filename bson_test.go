@@ -0,0 +1,54 @@
+package gimel
+
+import (
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBSONDecimal128(t *testing.T) {
+	// examples from the BSON corpus, the canonical bytes are little-endian
+	for _, i := range []struct {
+		s, b string
+	}{
+		{"1", "01000000000000000000000000004030"},
+		{"-1", "010000000000000000000000000040b0"},
+		{"0", "00000000000000000000000000004030"},
+		{"-0", "000000000000000000000000000040b0"},
+		{"0.1", "01000000000000000000000000003e30"},
+		{"9.999999999999999999999999999999999e6144", "ffffffff638e8d37c087adbe09edff5f"},
+		{"1e-6176", "01000000000000000000000000000000"},
+		{"Infinity", "00000000000000000000000000000078"},
+		{"-Infinity", "000000000000000000000000000000f8"},
+		{"NaN", "0000000000000000000000000000007c"},
+	} {
+		g, err := Parse(i.s)
+		assert.NoError(t, err)
+		b, err := EncodeBSONDecimal128(g)
+		assert.NoError(t, err)
+		assert.Equal(t, i.b, hex.EncodeToString(b[:]), i.s)
+
+		a, err := DecodeBSONDecimal128(b[:])
+		assert.NoError(t, err)
+		assert.Equal(t, g.String(), a.String(), i.s)
+	}
+
+	// the exponent is clamped to the largest exponent by adding zeros
+	g, _ := Parse("1e6144")
+	b, err := EncodeBSONDecimal128(g)
+	assert.NoError(t, err)
+	a, err := DecodeBSONDecimal128(b[:])
+	assert.NoError(t, err)
+	assert.Equal(t, "1e6144", a.String())
+
+	// the 35th digit is rounded
+	g, _ = Parse("1.2345678901234567890123456789012345")
+	b, err = EncodeBSONDecimal128(g)
+	assert.NoError(t, err)
+	a, err = DecodeBSONDecimal128(b[:])
+	assert.NoError(t, err)
+	assert.Equal(t, "1.234567890123456789012345678901234e0", a.String())
+
+	_, err = DecodeBSONDecimal128(make([]byte, 8))
+	assert.Error(t, err)
+}
@@ -0,0 +1,324 @@
+package gimel
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// CBOR major types and tags
+const (
+	cborUint   = 0
+	cborNegInt = 1
+	cborBytes  = 2
+	cborArray  = 4
+	cborTag    = 6
+	cborSimple = 7
+
+	cborTagPosBignum  = 2
+	cborTagNegBignum  = 3
+	cborTagDecimal    = 4
+	cborTagBigfloat   = 5
+	cborFloat16       = 25
+	cborFloat32       = 26
+	cborFloat64       = 27
+	cborFloat16PosInf = 0x7c00
+	cborFloat16NegInf = 0xfc00
+	cborFloat16NaN    = 0x7e00
+	cborFloat16NegZ   = 0x8000
+
+	// cborMaxExp is the largest power of two allowed when decoding a bigfloat
+	cborMaxExp = 1 << 20
+)
+
+var (
+	errInvalidCBOR = errors.New("gimel: invalid CBOR number")
+	errCBORRange   = errors.New("gimel: number is out of range for CBOR")
+	errCBORInexact = errors.New("gimel: number is not exactly representable as a CBOR bigfloat")
+)
+
+// EncodeCBOR returns g as a CBOR decimal fraction, tag 4 with the array [exponent, mantissa]
+//
+// The mantissa has no trailing zeros and uses a bignum, tag 2 or 3, if it
+// doesn't fit in 64 bits. Negative zero, the infinities and NaN are encoded as
+// half-precision floats as CBOR has no decimal form for them.
+func EncodeCBOR(g Gimel) ([]byte, error) {
+	if err := checkPrec("cbor", g); err != nil {
+		return nil, err
+	}
+	if b, ok := g.cborSpecial(); ok {
+		return b, nil
+	}
+	if g.digits.Sign() == 0 {
+		return appendCBORFraction(cborTagDecimal, false, new(big.Int), new(big.Int))
+	}
+	ds := g.digits.String()
+	ms := strings.TrimRight(ds, "0")
+	m, _ := new(big.Int).SetString(ms, 10)
+	e := new(big.Int).Add(g.unitExp(), big.NewInt(int64(len(ds)-len(ms))))
+	return appendCBORFraction(cborTagDecimal, g.neg, e, m)
+}
+
+// EncodeCBORBigfloat returns g as a CBOR bigfloat, tag 5 with the array [exponent, mantissa] for mantissa*2^exponent
+//
+// The mantissa is odd and an error is returned if g isn't a finite sum of powers
+// of two. Zero, negative zero, the infinities and NaN are encoded the same way
+// as EncodeCBOR.
+func EncodeCBORBigfloat(g Gimel) ([]byte, error) {
+	if err := checkPrec("cbor", g); err != nil {
+		return nil, err
+	}
+	if b, ok := g.cborSpecial(); ok {
+		return b, nil
+	}
+	if g.digits.Sign() == 0 {
+		return appendCBORFraction(cborTagBigfloat, false, new(big.Int), new(big.Int))
+	}
+	m, e, err := g.dyadic(cborMaxExp, errCBORRange, errCBORInexact)
+	if err != nil {
+		return nil, err
+	}
+	return appendCBORFraction(cborTagBigfloat, g.neg, e, m)
+}
+
+// cborSpecial is an internal function to get the half-precision float for negative zero, infinity or NaN
+func (g Gimel) cborSpecial() ([]byte, bool) {
+	var h uint16
+	switch {
+	case g.IsNaN():
+		h = cborFloat16NaN
+	case g.IsInf() && g.neg:
+		h = cborFloat16NegInf
+	case g.IsInf():
+		h = cborFloat16PosInf
+	case g.digits.Sign() == 0 && g.neg:
+		h = cborFloat16NegZ
+	default:
+		return nil, false
+	}
+	return binary.BigEndian.AppendUint16([]byte{cborSimple<<5 | cborFloat16}, h), true
+}
+
+// appendCBORFraction is an internal function to encode a decimal fraction or bigfloat
+// the exponent must fit in 64 bits and the mantissa m is the absolute value
+func appendCBORFraction(tag uint64, neg bool, e, m *big.Int) ([]byte, error) {
+	if !e.IsInt64() {
+		return nil, errCBORRange
+	}
+	b := appendCBORHead(nil, cborTag, tag)
+	b = appendCBORHead(b, cborArray, 2)
+	b = appendCBORInt(b, e.Sign() == -1, new(big.Int).Abs(e))
+	return appendCBORInt(b, neg, m), nil
+}
+
+// appendCBORHead is an internal function to append the head of a CBOR data item with the shortest argument
+func appendCBORHead(b []byte, major byte, n uint64) []byte {
+	major <<= 5
+	switch {
+	case n < 24:
+		return append(b, major|byte(n))
+	case n <= math.MaxUint8:
+		return append(b, major|24, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, major|25), uint16(n))
+	case n <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, major|26), uint32(n))
+	}
+	return binary.BigEndian.AppendUint64(append(b, major|27), n)
+}
+
+// appendCBORInt is an internal function to append the integer n or -n as a CBOR integer or bignum
+func appendCBORInt(b []byte, neg bool, n *big.Int) []byte {
+	major, tag := byte(cborUint), uint64(cborTagPosBignum)
+	v := new(big.Int).Set(n)
+	if neg && n.Sign() != 0 {
+		// negative integers are encoded as -1-v
+		major, tag = cborNegInt, cborTagNegBignum
+		v.Sub(v, oneValue)
+	}
+	if v.IsUint64() {
+		return appendCBORHead(b, major, v.Uint64())
+	}
+	b = appendCBORHead(b, cborTag, tag)
+	b = appendCBORHead(b, cborBytes, uint64(len(v.Bytes())))
+	return append(b, v.Bytes()...)
+}
+
+// cborReader is an internal type to read CBOR data items
+type cborReader []byte
+
+// head is an internal function to read the major type, additional information and argument of a data item
+// indefinite lengths are not supported
+func (r *cborReader) head() (byte, byte, uint64, error) {
+	if len(*r) == 0 {
+		return 0, 0, 0, errInvalidCBOR
+	}
+	major, info := (*r)[0]>>5, (*r)[0]&0x1f
+	*r = (*r)[1:]
+	if info < 24 {
+		return major, info, uint64(info), nil
+	}
+	if info > 27 {
+		return 0, 0, 0, errInvalidCBOR
+	}
+	n := 1 << (info - 24)
+	if len(*r) < n {
+		return 0, 0, 0, errInvalidCBOR
+	}
+	var v uint64
+	for _, c := range (*r)[:n] {
+		v = v<<8 | uint64(c)
+	}
+	*r = (*r)[n:]
+	return major, info, v, nil
+}
+
+// integer is an internal function to read a CBOR integer or bignum
+func (r *cborReader) integer() (*big.Int, error) {
+	major, _, n, err := r.head()
+	if err != nil {
+		return nil, err
+	}
+	v := new(big.Int)
+	switch {
+	case major == cborUint, major == cborNegInt:
+		v.SetUint64(n)
+	case major == cborTag && (n == cborTagPosBignum || n == cborTagNegBignum):
+		major = cborUint
+		if n == cborTagNegBignum {
+			major = cborNegInt
+		}
+		m, _, l, err := r.head()
+		if err != nil || m != cborBytes || uint64(len(*r)) < l {
+			return nil, errInvalidCBOR
+		}
+		v.SetBytes((*r)[:l])
+		*r = (*r)[l:]
+	default:
+		return nil, errInvalidCBOR
+	}
+	if major == cborNegInt {
+		// negative integers are encoded as -1-v
+		v.Add(v, oneValue)
+		v.Neg(v)
+	}
+	return v, nil
+}
+
+// DecodeCBOR returns the Gimel number from a CBOR data item
+//
+// Decimal fractions, bigfloats, integers, bignums and floats are accepted. If
+// prec is nil then the precision is the number of digits needed to represent
+// the value exactly.
+func DecodeCBOR(b []byte, prec *big.Int) (Gimel, error) {
+	r := cborReader(b)
+	g, err := r.number(prec)
+	if err != nil {
+		return Gimel{}, err
+	}
+	if len(r) != 0 {
+		return Gimel{}, errInvalidCBOR
+	}
+	return g, nil
+}
+
+// number is an internal function to read a CBOR data item as a Gimel number
+func (r *cborReader) number(prec *big.Int) (Gimel, error) {
+	first := *r
+	major, info, n, err := r.head()
+	if err != nil {
+		return Gimel{}, err
+	}
+	switch {
+	case major == cborSimple:
+		return fromCBORFloat(info, n, prec)
+	case major != cborTag || n != cborTagDecimal && n != cborTagBigfloat:
+		// integers and bignums
+		*r = first
+		m, err := r.integer()
+		if err != nil {
+			return Gimel{}, err
+		}
+		neg := m.Sign() == -1
+		return fromCoef(neg, m.Abs(m), zeroValue, prec), nil
+	}
+
+	if a, _, l, err := r.head(); err != nil || a != cborArray || l != 2 {
+		return Gimel{}, errInvalidCBOR
+	}
+	if len(*r) == 0 || (*r)[0]>>5 > cborNegInt {
+		// the exponent can't be a bignum
+		return Gimel{}, errInvalidCBOR
+	}
+	e, err := r.integer()
+	if err != nil {
+		return Gimel{}, err
+	}
+	m, err := r.integer()
+	if err != nil {
+		return Gimel{}, err
+	}
+	neg := m.Sign() == -1
+	if n == cborTagDecimal {
+		return fromCoef(neg, m.Abs(m), e, prec), nil
+	}
+	if e.CmpAbs(big.NewInt(cborMaxExp)) == 1 {
+		return Gimel{}, errCBORRange
+	}
+	return cborDyadic(neg, m.Abs(m), e.Int64(), prec), nil
+}
+
+// fromCBORFloat is an internal function to return the Gimel number from the bits of a CBOR float
+func fromCBORFloat(info byte, bits uint64, prec *big.Int) (Gimel, error) {
+	var f float64
+	switch info {
+	case cborFloat16:
+		// half-precision floats have 10 fraction bits and an exponent bias of 15
+		frac, exp := float64(bits&0x3ff), int(bits>>10&0x1f)
+		switch exp {
+		case 0:
+			f = math.Ldexp(frac, -24)
+		case 0x1f:
+			f = math.Inf(1)
+			if frac != 0 {
+				f = math.NaN()
+			}
+		default:
+			f = math.Ldexp(frac+0x400, exp-25)
+		}
+		if bits&0x8000 != 0 {
+			f = -f
+		}
+	case cborFloat32:
+		f = float64(math.Float32frombits(uint32(bits)))
+	case cborFloat64:
+		f = math.Float64frombits(bits)
+	default:
+		return Gimel{}, errInvalidCBOR
+	}
+
+	switch {
+	case math.IsNaN(f):
+		return NaN(specialPrec(prec)), nil
+	case math.IsInf(f, 0):
+		return Inf(f < 0, specialPrec(prec)), nil
+	case f == 0:
+		return fromCoef(math.Signbit(f), new(big.Int), zeroValue, prec), nil
+	}
+	frac, exp := math.Frexp(math.Abs(f))
+	m := new(big.Int).SetUint64(uint64(math.Ldexp(frac, 53)))
+	return cborDyadic(math.Signbit(f), m, int64(exp-53), prec), nil
+}
+
+// cborDyadic is an internal function to return the Gimel number c*2^t without trailing zero bits in c
+// if prec is nil then the precision is the number of digits needed to represent the value exactly
+func cborDyadic(neg bool, c *big.Int, t int64, prec *big.Int) Gimel {
+	c = new(big.Int).Set(c)
+	if z := c.TrailingZeroBits(); c.Sign() != 0 && z > 0 {
+		c.Rsh(c, z)
+		t += int64(z)
+	}
+	return fromDyadic(neg, c, t, prec)
+}
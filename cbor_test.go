@@ -0,0 +1,116 @@
+package gimel
+
+import (
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+)
+
+func TestEncodeCBOR(t *testing.T) {
+	for _, i := range []struct {
+		g Gimel
+		b string
+	}{
+		// the example from RFC 8949
+		{gen(false, 27315, 2), "c48221196ab3"},
+		{gen(true, 15, 0), "c482202e"},
+		{gen(false, 12, 3), "c482020c"},
+		{gen(false, 0, 0), "c4820000"},
+		{gen(true, 0, 0), "f98000"},
+		{Inf(false, prec), "f97c00"},
+		{Inf(true, prec), "f9fc00"},
+		{NaN(prec), "f97e00"},
+		{G(false, strToBigInt("18446744073709551616"), big.NewInt(19), big.NewInt(20)), "c48200c249010000000000000000"},
+		{G(true, strToBigInt("18446744073709551617"), big.NewInt(19), big.NewInt(20)), "c48200c349010000000000000000"},
+	} {
+		b, err := EncodeCBOR(i.g)
+		assert.NoError(t, err)
+		assert.Equal(t, i.b, hex.EncodeToString(b), i.g.String())
+
+		a, err := DecodeCBOR(b, nil)
+		assert.NoError(t, err)
+		if i.g.IsNaN() {
+			assert.True(t, a.IsNaN())
+		} else {
+			assert.True(t, i.g.Eq(a), i.g.String())
+			assert.Equal(t, i.g.IsNeg(), a.IsNeg(), i.g.String())
+		}
+	}
+
+	_, err := EncodeCBOR(Gimel{})
+	assert.Error(t, err)
+}
+
+func TestEncodeCBORBigfloat(t *testing.T) {
+	for _, i := range []struct {
+		g Gimel
+		b string
+	}{
+		// the example from RFC 8949
+		{gen(false, 15, 0), "c5822003"},
+		{gen(true, 75, 0), "c582202e"},
+		{gen(false, 12, 3), "c58204184b"},
+		{gen(false, 0, 0), "c5820000"},
+		{Inf(false, prec), "f97c00"},
+	} {
+		b, err := EncodeCBORBigfloat(i.g)
+		assert.NoError(t, err)
+		assert.Equal(t, i.b, hex.EncodeToString(b), i.g.String())
+
+		a, err := DecodeCBOR(b, nil)
+		assert.NoError(t, err)
+		assert.True(t, i.g.Eq(a), i.g.String())
+	}
+
+	_, err := EncodeCBORBigfloat(gen(false, 1, -1))
+	assert.Equal(t, errCBORInexact, err)
+	_, err = EncodeCBORBigfloat(gen(false, 1, 1000000000))
+	assert.Equal(t, errCBORRange, err)
+}
+
+func TestDecodeCBOR(t *testing.T) {
+	for _, i := range []struct {
+		b string
+		s string
+	}{
+		{"17", "23"},
+		{"3863", "-100"},
+		{"c24101", "1"},
+		{"c34100", "-1"},
+		{"c4823863c24101", "1e-100"},
+		{"f93e00", "1.5"},
+		{"fa3fc00000", "1.5"},
+		{"fb3fb999999999999a", "0.1000000000000000055511151231257827021181583404541015625"},
+		{"f90001", "5.9604644775390625e-8"},
+		{"f90000", "0"},
+		{"fa7f800000", "Inf"},
+		{"fbfff0000000000000", "-Inf"},
+		{"f97e01", "NaN"},
+	} {
+		b, _ := hex.DecodeString(i.b)
+		g, err := DecodeCBOR(b, nil)
+		assert.NoError(t, err)
+		a, err := Parse(i.s)
+		assert.NoError(t, err)
+		if a.IsNaN() {
+			assert.True(t, g.IsNaN(), i.b)
+		} else {
+			assert.True(t, a.Eq(g), i.b)
+		}
+	}
+
+	g, err := DecodeCBOR([]byte{0x19, 0x01, 0x00}, big.NewInt(5))
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(5), g.prec)
+	assert.True(t, g.Eq(gen(false, 256, 2)))
+
+	for _, i := range []string{
+		"", "1700", "18", "1f", "40", "f5", "c482", "c48101", "c4830101",
+		"c482c2410101", "c443010203", "c24201", "c582c2410101", "c5821a0010000101",
+	} {
+		b, _ := hex.DecodeString(i)
+		_, err := DecodeCBOR(b, nil)
+		assert.Error(t, err, i)
+	}
+}
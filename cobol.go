@@ -0,0 +1,172 @@
+package gimel
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+)
+
+// ZonedEncoding is the character set of a zoned decimal field
+type ZonedEncoding byte
+
+const (
+	EBCDIC ZonedEncoding = iota // digits are 0xF0-0xF9 and the sign is the zone of the last digit
+	ASCII                       // digits are '0'-'9' and the sign is overpunched on the last digit as {A-I or }J-R
+)
+
+const (
+	// packed sign nibbles
+	packedPos      = 0xc
+	packedNeg      = 0xd
+	packedUnsigned = 0xf
+
+	// asciiPos and asciiNeg are the overpunched characters for the last digit 0-9
+	asciiPos = "{ABCDEFGHI"
+	asciiNeg = "}JKLMNOPQR"
+)
+
+var (
+	errFieldRange   = errors.New("gimel: number is out of range for the decimal field")
+	errInvalidField = errors.New("gimel: invalid decimal field")
+)
+
+// fieldDigits is an internal function to get the digits of g*10^scale padded to n digits for a decimal field
+// the sign is false for zero or if the field is unsigned
+func fieldDigits(g Gimel, n, scale int, signed bool) (string, bool, error) {
+	if err := checkPrec("field", g); err != nil {
+		return "", false, err
+	}
+	if !g.IsFinite() || n < 1 {
+		return "", false, errFieldRange
+	}
+	d, ok := g.scaledDigits(int64(scale), int64(n))
+	if !ok {
+		return "", false, errFieldRange
+	}
+	neg := g.neg && d.Sign() != 0
+	s := d.String()
+	if len(s) > n || (neg && !signed) {
+		return "", false, errFieldRange
+	}
+	return strings.Repeat("0", n-len(s)) + s, neg, nil
+}
+
+// fieldNumber is an internal function to return the Gimel number from the digits of a decimal field
+// the precision is the number of digits in the field
+func fieldNumber(neg bool, s string, scale int) Gimel {
+	c, _ := new(big.Int).SetString(s, 10)
+	return fromCoef(neg, c, big.NewInt(int64(-scale)), big.NewInt(int64(len(s))))
+}
+
+// EncodePacked returns g as a packed decimal (COBOL COMP-3) field with n digits and an implied scale
+//
+// Each byte holds two BCD digits and the last nibble is the sign, C for
+// positive and D for negative, or F if the field is unsigned. A leading zero
+// nibble is added if n is even. The number is rounded to the scale using its
+// rounding mode.
+func EncodePacked(g Gimel, n, scale int, signed bool) ([]byte, error) {
+	s, neg, err := fieldDigits(g, n, scale, signed)
+	if err != nil {
+		return nil, err
+	}
+	if n%2 == 0 {
+		s = "0" + s
+	}
+	b := make([]byte, (len(s)+1)/2)
+	for i := 0; i < len(s); i++ {
+		b[i/2] |= (s[i] - '0') << (4 * (1 - i%2))
+	}
+	switch {
+	case !signed:
+		b[len(b)-1] |= packedUnsigned
+	case neg:
+		b[len(b)-1] |= packedNeg
+	default:
+		b[len(b)-1] |= packedPos
+	}
+	return b, nil
+}
+
+// DecodePacked returns the Gimel number from a packed decimal (COBOL COMP-3) field with n digits and an implied scale
+// the sign nibbles B and D are negative, A, C, E and F are positive
+func DecodePacked(b []byte, n, scale int) (Gimel, error) {
+	if n < 1 || len(b) != n/2+1 {
+		return Gimel{}, errInvalidField
+	}
+	var s strings.Builder
+	for i := 0; i < 2*len(b)-1; i++ {
+		d := b[i/2] >> (4 * (1 - i%2)) & 0xf
+		if d > 9 || (n%2 == 0 && i == 0 && d != 0) {
+			return Gimel{}, errInvalidField
+		}
+		if n%2 == 1 || i > 0 {
+			s.WriteByte('0' + d)
+		}
+	}
+	sign := b[len(b)-1] & 0xf
+	if sign < 0xa {
+		return Gimel{}, errInvalidField
+	}
+	return fieldNumber(sign == 0xb || sign == packedNeg, s.String(), scale), nil
+}
+
+// EncodeZoned returns g as a zoned decimal field with n digits and an implied scale
+//
+// Each digit is a character and the sign is stored with the last digit, EBCDIC
+// uses the zones C and D or F if the field is unsigned, ASCII uses the
+// overpunched characters {A-I and }J-R or plain digits if the field is unsigned.
+func EncodeZoned(g Gimel, n, scale int, signed bool, enc ZonedEncoding) ([]byte, error) {
+	s, neg, err := fieldDigits(g, n, scale, signed)
+	if err != nil {
+		return nil, err
+	}
+	b := []byte(s)
+	last := b[n-1] - '0'
+	if enc == EBCDIC {
+		for i := range b {
+			b[i] = 0xf0 | (b[i] - '0')
+		}
+		switch {
+		case !signed:
+		case neg:
+			b[n-1] = packedNeg<<4 | last
+		default:
+			b[n-1] = packedPos<<4 | last
+		}
+	} else if signed {
+		if neg {
+			b[n-1] = asciiNeg[last]
+		} else {
+			b[n-1] = asciiPos[last]
+		}
+	}
+	return b, nil
+}
+
+// DecodeZoned returns the Gimel number from a zoned decimal field and an implied scale
+// the number of digits is the length of the field, unsigned and signed fields are both accepted
+func DecodeZoned(b []byte, scale int, enc ZonedEncoding) (Gimel, error) {
+	if len(b) == 0 {
+		return Gimel{}, errInvalidField
+	}
+	s := make([]byte, len(b))
+	var neg bool
+	for i, c := range b {
+		last := i == len(b)-1
+		switch {
+		case enc == EBCDIC && c&0xf <= 9 && (c>>4 == 0xf || last && c>>4 >= 0xa):
+			s[i] = '0' + c&0xf
+			neg = last && (c>>4 == 0xb || c>>4 == packedNeg)
+		case enc == ASCII && c >= '0' && c <= '9':
+			s[i] = c
+		case enc == ASCII && last && strings.IndexByte(asciiPos, c) != -1:
+			s[i] = '0' + byte(strings.IndexByte(asciiPos, c))
+		case enc == ASCII && last && strings.IndexByte(asciiNeg, c) != -1:
+			s[i] = '0' + byte(strings.IndexByte(asciiNeg, c))
+			neg = true
+		default:
+			return Gimel{}, errInvalidField
+		}
+	}
+	return fieldNumber(neg, string(s), scale), nil
+}
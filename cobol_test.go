@@ -0,0 +1,104 @@
+package gimel
+
+import (
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+)
+
+func TestPacked(t *testing.T) {
+	for _, i := range []struct {
+		s        string
+		n, scale int
+		signed   bool
+		b        string
+	}{
+		{"123.45", 5, 2, true, "12345c"},
+		{"-123.45", 5, 2, true, "12345d"},
+		{"123.45", 5, 2, false, "12345f"},
+		{"-1.5", 4, 1, true, "00015d"},
+		{"0", 3, 0, true, "000c"},
+		{"1234", 7, 0, true, "0001234c"},
+	} {
+		g, err := Parse(i.s)
+		assert.NoError(t, err)
+		b, err := EncodePacked(g, i.n, i.scale, i.signed)
+		assert.NoError(t, err)
+		assert.Equal(t, i.b, hex.EncodeToString(b), i.s)
+
+		a, err := DecodePacked(b, i.n, i.scale)
+		assert.NoError(t, err)
+		assert.True(t, g.Eq(a), i.s)
+		assert.Equal(t, big.NewInt(int64(i.n)), a.prec)
+	}
+
+	// the scale rounds with the rounding mode
+	b, err := EncodePacked(gen(false, 12355, 1), 3, 0, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "012c", hex.EncodeToString(b))
+
+	_, err = EncodePacked(gen(false, 1, 3), 3, 0, true)
+	assert.Error(t, err)
+	_, err = EncodePacked(gen(false, 1, 30000000), 3, 0, true)
+	assert.Error(t, err)
+	b, err = EncodePacked(gen(false, 1, -30000000), 3, 0, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "000c", hex.EncodeToString(b))
+	_, err = EncodePacked(gen(true, 1, 0), 3, 0, false)
+	assert.Error(t, err)
+	_, err = EncodePacked(NaN(prec), 3, 0, true)
+	assert.Error(t, err)
+
+	a, err := DecodePacked([]byte{0x12, 0x3b}, 3, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "-1.23e1", a.String())
+	for _, b := range [][]byte{{0x12, 0x3c}, {0x1a, 0x3c}, {0x12, 0x33}, {0x10, 0x01, 0x2c}} {
+		_, err = DecodePacked(b, 4, 0)
+		assert.Error(t, err, b)
+	}
+}
+
+func TestZoned(t *testing.T) {
+	for _, i := range []struct {
+		s        string
+		n, scale int
+		signed   bool
+		ebcdic   string
+		ascii    string
+	}{
+		{"123.45", 5, 2, true, "f1f2f3f4c5", "1234E"},
+		{"-123.45", 5, 2, true, "f1f2f3f4d5", "1234N"},
+		{"-1.20", 4, 2, true, "f0f1f2d0", "012}"},
+		{"12", 4, 0, false, "f0f0f1f2", "0012"},
+		{"0", 2, 0, true, "f0c0", "0{"},
+	} {
+		g, err := Parse(i.s)
+		assert.NoError(t, err)
+		b, err := EncodeZoned(g, i.n, i.scale, i.signed, EBCDIC)
+		assert.NoError(t, err)
+		assert.Equal(t, i.ebcdic, hex.EncodeToString(b), i.s)
+		a, err := DecodeZoned(b, i.scale, EBCDIC)
+		assert.NoError(t, err)
+		assert.True(t, g.Eq(a), i.s)
+
+		b, err = EncodeZoned(g, i.n, i.scale, i.signed, ASCII)
+		assert.NoError(t, err)
+		assert.Equal(t, i.ascii, string(b), i.s)
+		a, err = DecodeZoned(b, i.scale, ASCII)
+		assert.NoError(t, err)
+		assert.True(t, g.Eq(a), i.s)
+		assert.Equal(t, big.NewInt(int64(i.n)), a.prec)
+	}
+
+	for _, b := range []string{"", "1A3", "12x", "f1"} {
+		_, err := DecodeZoned([]byte(b), 0, ASCII)
+		assert.Error(t, err, b)
+	}
+	_, err := DecodeZoned([]byte{0xf1, 0xc2, 0xf3}, 0, EBCDIC)
+	assert.Error(t, err)
+	_, err = EncodeZoned(gen(false, 1, 3), 3, 0, true, ASCII)
+	assert.Error(t, err)
+	_, err = EncodeZoned(gen(false, 1, 30000000), 3, 0, true, ASCII)
+	assert.Error(t, err)
+}
@@ -0,0 +1,204 @@
+package gimel
+
+import (
+	"math/big"
+	"strings"
+)
+
+// Context holds the arithmetic policy used to run a whole computation
+//
+// Operations on a Context ignore the precision and rounding mode carried by
+// the operands, the result is calculated from the exact operands and then
+// rounded to Prec digits using Rounding.
+//
+// Every condition raised by an operation is added to Flags, a Context should
+// not be shared between goroutines. Prec must be positive, the operations of a
+// Context without a precision return an *OpError wrapping ErrPrecision.
+type Context struct {
+	Prec     *big.Int     // number of significant digits in each result
+	Rounding RoundingMode // rounding mode used to discard digits
+	MaxExp   *big.Int     // largest allowed exponent, nil for no limit
+	MinExp   *big.Int     // smallest allowed exponent for normal numbers, nil for no limit
+	Traps    Condition    // conditions which are returned as an error
+	Flags    Condition    // conditions raised since the flags were last cleared
+}
+
+// Condition is a set of exceptional conditions raised by Context operations
+type Condition uint32
+
+const (
+	Overflow         Condition = 1 << iota // the exponent of the result is larger than MaxExp
+	Underflow                              // the result is subnormal and inexact
+	Inexact                                // non-zero digits were discarded
+	Rounded                                // digits were discarded
+	Subnormal                              // the exponent of the result is smaller than MinExp
+	DivisionByZero                         // a non-zero number was divided by zero
+	InvalidOperation                       // the operation has no defined result
+)
+
+var conditionNames = []struct {
+	Condition
+	string
+}{
+	{Overflow, "overflow"},
+	{Underflow, "underflow"},
+	{Inexact, "inexact"},
+	{Rounded, "rounded"},
+	{Subnormal, "subnormal"},
+	{DivisionByZero, "division by zero"},
+	{InvalidOperation, "invalid operation"},
+}
+
+// String returns the names of the conditions in the set
+func (c Condition) String() string {
+	var a []string
+	for _, i := range conditionNames {
+		if c&i.Condition != 0 {
+			a = append(a, i.string)
+		}
+	}
+	return strings.Join(a, ", ")
+}
+
+// ConditionError is returned by Context operations which raise a trapped condition
+type ConditionError struct {
+	Op        string
+	Condition Condition
+}
+
+func (e *ConditionError) Error() string {
+	return "gimel: " + e.Op + ": " + e.Condition.String()
+}
+
+// opFunc is an internal function type for an operation rounded to prec digits
+type opFunc func(prec *big.Int, mode RoundingMode) (Gimel, Condition)
+
+// Round returns x rounded to the precision of the Context
+func (c *Context) Round(x Gimel) (Gimel, error) {
+	return c.apply("round", x.round)
+}
+
+// Add returns the sum x+y
+func (c *Context) Add(x, y Gimel) (Gimel, error) {
+	return c.apply("add", func(prec *big.Int, mode RoundingMode) (Gimel, Condition) {
+		return x.add(y, prec, mode)
+	})
+}
+
+// Sub returns the difference x-y
+func (c *Context) Sub(x, y Gimel) (Gimel, error) {
+	y = y.Neg()
+	return c.apply("sub", func(prec *big.Int, mode RoundingMode) (Gimel, Condition) {
+		return x.add(y, prec, mode)
+	})
+}
+
+// Mul returns the product x*y
+func (c *Context) Mul(x, y Gimel) (Gimel, error) {
+	return c.apply("mul", func(prec *big.Int, mode RoundingMode) (Gimel, Condition) {
+		return x.mul(y, prec, mode)
+	})
+}
+
+// Div returns the quotient x/y
+//
+// Dividing by zero raises DivisionByZero and returns ±Inf, 0/0 raises
+// InvalidOperation and returns NaN.
+func (c *Context) Div(x, y Gimel) (Gimel, error) {
+	return c.apply("div", func(prec *big.Int, mode RoundingMode) (Gimel, Condition) {
+		return x.div(y, prec, mode)
+	})
+}
+
+// Ln returns the natural logarithm of x
+//
+// Negative numbers raise InvalidOperation and return NaN, zero raises
+// DivisionByZero and returns -Inf.
+func (c *Context) Ln(x Gimel) (Gimel, error) {
+	return c.apply("ln", x.ln)
+}
+
+// Log returns the logarithm of x using a base
+//
+// The conditions are the same as ln(x) / ln(base).
+func (c *Context) Log(x, base Gimel) (Gimel, error) {
+	return c.apply("log", func(prec *big.Int, mode RoundingMode) (Gimel, Condition) {
+		return x.log(base, prec, mode)
+	})
+}
+
+// Log10 returns the logarithm of x with base 10
+func (c *Context) Log10(x Gimel) (Gimel, error) {
+	return c.apply("log10", func(prec *big.Int, mode RoundingMode) (Gimel, Condition) {
+		return x.log(G(false, big.NewInt(1), big.NewInt(1), prec), prec, mode)
+	})
+}
+
+// Exp returns e^x where e is Euler's number
+func (c *Context) Exp(x Gimel) (Gimel, error) {
+	return c.apply("exp", x.exponential)
+}
+
+// apply is an internal function to run an operation and apply the exponent limits to the result
+func (c *Context) apply(op string, fn opFunc) (Gimel, error) {
+	if c.Prec == nil || c.Prec.Sign() != 1 {
+		return Gimel{}, &OpError{op, ErrPrecision}
+	}
+	r, cond := fn(c.Prec, c.Rounding)
+	if r.form == finite && r.digits.Sign() != 0 {
+		switch {
+		case c.MaxExp != nil && r.exp.Cmp(c.MaxExp) == 1:
+			// the rounding mode decides between infinity and the largest finite number
+			cond |= Overflow | Inexact | Rounded
+			if c.Rounding.overflowToInf(r.neg) {
+				r = infResult(r.neg, c.Prec, c.Rounding)
+			} else {
+				r = g2(r.neg, new(big.Int).Sub(r.p10p, oneValue), new(big.Int).Set(c.MaxExp), c.Prec, c.Rounding)
+			}
+		case c.MinExp != nil && r.exp.Cmp(c.MinExp) == -1:
+			r, cond = c.subnormal(r, fn)
+		}
+	}
+	return c.finish(op, r, cond)
+}
+
+// subnormal is an internal function to recalculate a result with an exponent smaller than MinExp
+// the least significant digit of a subnormal result can't be smaller than MinExp-(Prec-1)
+// so the operation is run again with fewer digits
+func (c *Context) subnormal(r Gimel, fn opFunc) (Gimel, Condition) {
+	// p is the number of digits available above the smallest exponent
+	p := new(big.Int).Sub(r.exp, c.MinExp)
+	p.Add(p, c.Prec)
+
+	var cond Condition
+	if p.Sign() == 1 {
+		r, cond = fn(p, c.Rounding)
+		r = r.Precision(c.Prec)
+	} else {
+		// the result rounds to zero or the smallest subnormal number, rounding
+		// with 05up first keeps enough information to round again correctly
+		t, _ := fn(twoValue, Round05Up)
+		exp := new(big.Int).Sub(c.MinExp, c.Prec)
+		exp.Add(exp, oneValue)
+		n := new(big.Int).Sub(exp, t.unitExp())
+		c.Rounding.roundDigits(t.neg, t.digits, n)
+		r = g2(t.neg, t.digits, exp, oneValue, c.Rounding).normPrec().Precision(c.Prec)
+		cond = Inexact | Rounded
+	}
+
+	cond |= Subnormal
+	if cond&Inexact != 0 {
+		cond |= Underflow
+	}
+	return r, cond
+}
+
+// finish is an internal function to add the conditions to the flags
+// an error is returned if any raised condition is trapped
+func (c *Context) finish(op string, r Gimel, cond Condition) (Gimel, error) {
+	c.Flags |= cond
+	if cond&c.Traps != 0 {
+		return Gimel{}, &ConditionError{op, cond & c.Traps}
+	}
+	return r, nil
+}
@@ -0,0 +1,250 @@
+package gimel
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+)
+
+func TestContext_Precision(t *testing.T) {
+	ctx := &Context{Prec: big.NewInt(3)}
+
+	// operand precision is ignored
+	a, err := ctx.Div(gen(false, 2, 0), gen(false, 3, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, "6.67e-1", a.TextE())
+	assert.Equal(t, int64(3), a.prec.Int64())
+
+	a, err = ctx.Add(Pi, Euler)
+	assert.NoError(t, err)
+	assert.Equal(t, "5.86e0", a.TextE())
+
+	a, err = ctx.Sub(gen(false, 1, 10), gen(false, 1, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, "1e10", a.TextE())
+
+	a, err = ctx.Mul(Pi, Pi)
+	assert.NoError(t, err)
+	assert.Equal(t, "9.87e0", a.TextE())
+
+	a, err = ctx.Round(Pi)
+	assert.NoError(t, err)
+	assert.Equal(t, "3.14e0", a.TextE())
+}
+
+func TestContext_Rounding(t *testing.T) {
+	ctx := &Context{Prec: big.NewInt(3), Rounding: RoundDown}
+	a, err := ctx.Sub(gen(false, 1, 10), gen(false, 1, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, "9.99e9", a.TextE())
+
+	ctx.Rounding = RoundUp
+	a, err = ctx.Div(gen(false, 1, 0), gen(false, 3, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, "3.34e-1", a.TextE())
+}
+
+func TestContext_Transcendental(t *testing.T) {
+	ctx := &Context{Prec: big.NewInt(20)}
+
+	a, err := ctx.Ln(gen(false, 2, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, "6.9314718055994530942e-1", a.TextE())
+
+	a, err = ctx.Exp(gen(false, 1, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, "2.7182818284590452354e0", a.TextE())
+
+	a, err = ctx.Ln(Euler)
+	assert.NoError(t, err)
+	assert.Equal(t, "1e0", a.TextE())
+
+	a, err = ctx.Log10(gen(false, 1, 7))
+	assert.NoError(t, err)
+	assert.Equal(t, "7e0", a.TextE())
+
+	a, err = ctx.Log(gen(false, 8, 0), gen(false, 2, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, "3e0", a.TextE())
+}
+
+func TestContext_ExponentLimits(t *testing.T) {
+	ctx := &Context{Prec: big.NewInt(3), MaxExp: big.NewInt(10), MinExp: big.NewInt(-10)}
+
+	a, err := ctx.Mul(gen(false, 2, 6), gen(false, 3, 6))
+	assert.NoError(t, err)
+	assert.Equal(t, "Infinity", a.TextE())
+	assert.Equal(t, Overflow|Inexact|Rounded, ctx.Flags)
+
+	// the rounding mode decides if overflow results in infinity
+	ctx.Rounding = RoundDown
+	a, err = ctx.Mul(gen(false, 2, 6), gen(false, 3, 6))
+	assert.NoError(t, err)
+	assert.Equal(t, "9.99e10", a.TextE())
+	ctx.Rounding = RoundFloor
+	a, err = ctx.Mul(gen(true, 2, 6), gen(false, 3, 6))
+	assert.NoError(t, err)
+	assert.Equal(t, "-Infinity", a.TextE())
+	a, err = ctx.Mul(gen(false, 2, 6), gen(false, 3, 6))
+	assert.NoError(t, err)
+	assert.Equal(t, "9.99e10", a.TextE())
+	ctx.Rounding = RoundHalfEven
+
+	// subnormal results lose digits below 1e-12
+	ctx.Flags = 0
+	a, err = ctx.Div(gen(false, 2, -6), gen(false, 3, 4))
+	assert.NoError(t, err)
+	assert.Equal(t, "6.7e-11", a.TextE())
+	assert.Equal(t, int64(3), a.prec.Int64())
+	assert.Equal(t, Subnormal|Underflow|Inexact|Rounded, ctx.Flags)
+
+	ctx.Flags = 0
+	a, err = ctx.Mul(gen(false, 2, -6), gen(false, 3, -5))
+	assert.NoError(t, err)
+	assert.Equal(t, "6e-11", a.TextE())
+	assert.Equal(t, Subnormal|Rounded, ctx.Flags)
+
+	// values below the smallest subnormal round to zero or 1e-12
+	ctx.Flags = 0
+	a, err = ctx.Div(gen(true, 2, -6), gen(false, 3, 6))
+	assert.NoError(t, err)
+	assert.Equal(t, "-1e-12", a.TextE())
+	a, err = ctx.Div(gen(true, 2, -7), gen(false, 3, 6))
+	assert.NoError(t, err)
+	assert.Equal(t, "-0", a.TextE())
+	assert.Equal(t, Subnormal|Underflow|Inexact|Rounded, ctx.Flags)
+
+	ctx.Traps = Overflow
+	_, err = ctx.Mul(gen(false, 2, 6), gen(false, 3, 6))
+	assert.Equal(t, &ConditionError{"mul", Overflow}, err)
+	assert.EqualError(t, err, "gimel: mul: overflow")
+
+	// underflow is not trapped
+	_, err = ctx.Div(gen(true, 2, -6), gen(false, 3, 6))
+	assert.NoError(t, err)
+}
+
+func TestContext_Flags(t *testing.T) {
+	ctx := &Context{Prec: big.NewInt(3)}
+	one := G(false, big.NewInt(1), big.NewInt(0), ctx.Prec)
+
+	_, err := ctx.Add(one, one)
+	assert.NoError(t, err)
+	assert.Equal(t, Condition(0), ctx.Flags)
+
+	// discarding zeros is only rounded
+	_, err = ctx.Round(gen(false, 12, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, Rounded, ctx.Flags)
+
+	_, err = ctx.Div(gen(false, 1, 0), gen(false, 3, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, Rounded|Inexact, ctx.Flags)
+
+	// flags accumulate until cleared
+	a, err := ctx.Div(gen(false, 1, 0), gen(true, 0, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, "-Infinity", a.TextE())
+	assert.Equal(t, Rounded|Inexact|DivisionByZero, ctx.Flags)
+
+	ctx.Flags = 0
+	a, err = ctx.Div(gen(false, 0, 0), gen(false, 0, 0))
+	assert.NoError(t, err)
+	assert.True(t, a.IsNaN())
+	assert.Equal(t, InvalidOperation, ctx.Flags)
+
+	ctx.Flags = 0
+	a, err = ctx.Ln(gen(false, 0, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, "-Infinity", a.TextE())
+	a, err = ctx.Log(gen(false, 2, 0), gen(false, 1, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, "Infinity", a.TextE())
+	assert.Equal(t, DivisionByZero|Inexact|Rounded, ctx.Flags)
+
+	ctx.Flags = 0
+	a, err = ctx.Ln(gen(true, 2, 0))
+	assert.NoError(t, err)
+	assert.True(t, a.IsNaN())
+	assert.Equal(t, InvalidOperation, ctx.Flags)
+
+	// quiet NaN operands don't raise any conditions
+	ctx.Flags = 0
+	a, err = ctx.Add(a, gen(false, 2, 0))
+	assert.NoError(t, err)
+	assert.True(t, a.IsNaN())
+	assert.Equal(t, Condition(0), ctx.Flags)
+
+	ctx.Traps = InvalidOperation
+	_, err = ctx.Mul(SNaN(prec), gen(false, 2, 0))
+	assert.Equal(t, &ConditionError{"mul", InvalidOperation}, err)
+	_, err = ctx.Mul(Inf(false, prec), gen(false, 0, 0))
+	assert.Equal(t, &ConditionError{"mul", InvalidOperation}, err)
+	ctx.Traps = 0
+
+	// exact transcendental results
+	ctx.Flags = 0
+	a, err = ctx.Ln(gen(false, 1, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, "0", a.TextE())
+	a, err = ctx.Exp(gen(false, 0, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, "1e0", a.TextE())
+	assert.Equal(t, Condition(0), ctx.Flags)
+
+	// trapped conditions are returned as an error
+	ctx.Traps = Inexact
+	_, err = ctx.Exp(gen(false, 1, 0))
+	assert.Equal(t, &ConditionError{"exp", Inexact}, err)
+	assert.Equal(t, Inexact|Rounded, ctx.Flags)
+}
+
+func TestContext_LogExact(t *testing.T) {
+	ctx := &Context{Prec: big.NewInt(10), Traps: Inexact}
+
+	// integer powers of the base are exact
+	for _, i := range []struct {
+		x, base Gimel
+		s       string
+	}{
+		{gen(false, 1, 3), gen(false, 1, 1), "3e0"},
+		{gen(false, 8, 0), gen(false, 2, 0), "3e0"},
+		{gen(false, 25, -1), gen(false, 2, 0), "-2e0"},
+		{gen(false, 1, -4), gen(false, 1, 1), "-4e0"},
+		{gen(false, 15625, 4), gen(false, 5, 0), "6e0"},
+		{gen(false, 8, 0), gen(false, 5, -1), "-3e0"},
+	} {
+		a, err := ctx.Log(i.x, i.base)
+		assert.NoError(t, err)
+		assert.Equal(t, i.s, a.TextE())
+	}
+	a, err := ctx.Log10(gen(false, 1, 3))
+	assert.NoError(t, err)
+	assert.Equal(t, "3e0", a.TextE())
+	assert.Equal(t, Condition(0), ctx.Flags)
+
+	// other results are inexact
+	_, err = ctx.Log(gen(false, 3, 0), gen(false, 2, 0))
+	assert.Equal(t, &ConditionError{"log", Inexact}, err)
+	_, err = ctx.Log(gen(false, 9, 0), gen(false, 2, 0))
+	assert.Equal(t, &ConditionError{"log", Inexact}, err)
+	assert.Equal(t, Inexact|Rounded, ctx.Flags)
+}
+
+func TestContext_NoPrecision(t *testing.T) {
+	for _, ctx := range []*Context{{}, {Prec: big.NewInt(0)}} {
+		_, err := ctx.Add(gen(false, 1, 0), gen(false, 2, 0))
+		assert.Equal(t, &OpError{"add", ErrPrecision}, err)
+		_, err = ctx.Log10(gen(false, 1, 3))
+		assert.Equal(t, &OpError{"log10", ErrPrecision}, err)
+		_, err = ctx.Round(gen(false, 1, 0))
+		assert.ErrorIs(t, err, ErrPrecision)
+		assert.Equal(t, Condition(0), ctx.Flags)
+	}
+}
+
+func TestCondition_String(t *testing.T) {
+	assert.Equal(t, "", Condition(0).String())
+	assert.Equal(t, "overflow, underflow", (Overflow | Underflow).String())
+	assert.Equal(t, "inexact, division by zero", (DivisionByZero | Inexact).String())
+}
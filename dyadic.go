@@ -0,0 +1,49 @@
+package gimel
+
+import "math/big"
+
+// dyadic is an internal function to get a finite non-zero number as n*2^e with an odd n
+// errRange is returned if the exponent is outside ±maxExp, this is checked before building
+// the power of five, and errInexact is returned if the number isn't a finite sum of powers of two
+func (g Gimel) dyadic(maxExp int64, errRange, errInexact error) (n, e *big.Int, err error) {
+	// digits*10^u is digits*5^u*2^u
+	u := g.unitExp()
+	limit := big.NewInt(maxExp)
+	if u.CmpAbs(limit) == 1 {
+		return nil, nil, errRange
+	}
+	n = new(big.Int).Set(g.digits)
+	p := new(big.Int).Exp(big.NewInt(5), new(big.Int).Abs(u), nil)
+	if u.Sign() == -1 {
+		var r big.Int
+		n.QuoRem(n, p, &r)
+		if r.Sign() != 0 {
+			return nil, nil, errInexact
+		}
+	} else {
+		n.Mul(n, p)
+	}
+	e = new(big.Int).Set(u)
+	if t := n.TrailingZeroBits(); t > 0 {
+		n.Rsh(n, t)
+		e.Add(e, big.NewInt(int64(t)))
+	}
+	if e.CmpAbs(limit) == 1 {
+		return nil, nil, errRange
+	}
+	return n, e, nil
+}
+
+// fromDyadic is an internal function to return the Gimel number c*2^t
+// if prec is nil then the precision is the number of digits needed to represent the value exactly
+func fromDyadic(neg bool, c *big.Int, t int64, prec *big.Int) Gimel {
+	c = new(big.Int).Set(c)
+	if t >= 0 {
+		c.Lsh(c, uint(t))
+		t = 0
+	} else {
+		// c*2^t is c*5^-t*10^t
+		c.Mul(c, new(big.Int).Exp(big.NewInt(5), big.NewInt(-t), nil))
+	}
+	return fromCoef(neg, c, big.NewInt(t), prec)
+}
@@ -0,0 +1,90 @@
+package gimel
+
+import (
+	"errors"
+	"math/big"
+	"strconv"
+)
+
+var (
+	// ErrDomain is returned when an operation has no defined result for its operands
+	ErrDomain = errors.New("argument out of domain")
+	// ErrDivByZero is returned when a non-zero number is divided by zero
+	ErrDivByZero = errors.New("division by zero")
+	// ErrPrecision is returned when an operand has no valid precision
+	ErrPrecision = errors.New("invalid precision")
+	// ErrSyntax is wrapped by a ParseError when the input is not a valid number
+	ErrSyntax = errors.New("invalid syntax")
+)
+
+// OpError records a failed operation, the underlying error is one of
+// ErrDomain, ErrDivByZero or ErrPrecision
+type OpError struct {
+	Op  string
+	Err error
+}
+
+func (e *OpError) Error() string {
+	return "gimel: " + e.Op + ": " + e.Err.Error()
+}
+
+func (e *OpError) Unwrap() error { return e.Err }
+
+// ParseError records a failed conversion from a string, the error wraps ErrSyntax
+type ParseError struct {
+	Input    string // the input string
+	Offset   int    // the byte offset of the unexpected rune
+	Rune     rune   // the unexpected rune, or -1 at the end of the input
+	Expected string // a description of the expected token
+}
+
+func (e *ParseError) Error() string {
+	found := "end of input"
+	if e.Rune != -1 {
+		found = strconv.QuoteRune(e.Rune)
+	}
+	return "gimel: parsing " + strconv.Quote(e.Input) + ": unexpected " + found +
+		" at offset " + strconv.Itoa(e.Offset) + ", expected " + e.Expected
+}
+
+func (e *ParseError) Unwrap() error { return ErrSyntax }
+
+// checkPrec is an internal function to check the operands have a usable precision
+func checkPrec(op string, a ...Gimel) error {
+	for _, i := range a {
+		if i.digits == nil || i.prec == nil || i.prec.Sign() != 1 {
+			return &OpError{op, ErrPrecision}
+		}
+	}
+	return nil
+}
+
+// condErr is an internal function to convert the conditions raised by an operation to an error
+// NaN results are always a domain error even if the NaN was propagated from an operand
+func condErr(op string, r Gimel, cond Condition) error {
+	switch {
+	case cond&DivisionByZero != 0:
+		return &OpError{op, ErrDivByZero}
+	case cond&InvalidOperation != 0 || r.IsNaN():
+		return &OpError{op, ErrDomain}
+	}
+	return nil
+}
+
+// binaryE is an internal function to implement the error-returning variants of binary operations
+func binaryE(op string, g, o Gimel, fn func(o Gimel, prec *big.Int, mode RoundingMode) (Gimel, Condition)) (Gimel, error) {
+	if err := checkPrec(op, g, o); err != nil {
+		return Gimel{}, err
+	}
+	r, cond := fn(o, minBigInt(g.prec, o.prec), g.mode)
+	return r, condErr(op, r, cond)
+}
+
+// unaryE is an internal function to implement the error-returning variants of unary operations
+func unaryE(op string, g Gimel, fn func(prec *big.Int, mode RoundingMode) (Gimel, Condition)) (Gimel, error) {
+	if err := checkPrec(op, g); err != nil {
+		return Gimel{}, err
+	}
+	r, cond := fn(g.prec, g.mode)
+	return r, condErr(op, r, cond)
+}
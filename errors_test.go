@@ -0,0 +1,101 @@
+package gimel
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+)
+
+func TestGimel_DivE(t *testing.T) {
+	a, err := gen(false, 1, 0).DivE(gen(false, 4, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, "2.5e-1", a.String())
+
+	_, err = gen(false, 1, 0).DivE(gen(false, 0, 0))
+	assert.True(t, errors.Is(err, ErrDivByZero))
+	assert.EqualError(t, err, "gimel: div: division by zero")
+
+	_, err = gen(false, 0, 0).DivE(gen(false, 0, 0))
+	assert.True(t, errors.Is(err, ErrDomain))
+
+	_, err = gen(false, 1, 0).DivE(Gimel{})
+	assert.Equal(t, &OpError{"div", ErrPrecision}, err)
+}
+
+func TestGimel_AddE(t *testing.T) {
+	a, err := gen(false, 1, 0).AddE(gen(false, 4, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, "5e0", a.String())
+	_, err = Inf(false, prec).SubE(Inf(false, prec))
+	assert.Equal(t, &OpError{"sub", ErrDomain}, err)
+	_, err = Inf(false, prec).MulE(gen(false, 0, 0))
+	assert.Equal(t, &OpError{"mul", ErrDomain}, err)
+
+	// propagated NaN values are also a domain error
+	_, err = NaN(prec).AddE(gen(false, 4, 0))
+	assert.Equal(t, &OpError{"add", ErrDomain}, err)
+}
+
+func TestGimel_LnE(t *testing.T) {
+	a, err := gen(false, 1, 0).LnE()
+	assert.NoError(t, err)
+	assert.Equal(t, "0", a.String())
+
+	_, err = gen(true, 1, 0).LnE()
+	assert.Equal(t, &OpError{"ln", ErrDomain}, err)
+	_, err = gen(false, 0, 0).LnE()
+	assert.Equal(t, &OpError{"ln", ErrDivByZero}, err)
+	_, err = gen(true, 1, 0).Log10E()
+	assert.Equal(t, &OpError{"log10", ErrDomain}, err)
+	_, err = gen(false, 2, 0).LogE(gen(false, 1, 0))
+	assert.Equal(t, &OpError{"log", ErrDivByZero}, err)
+
+	a, err = gen(false, 1, 3).Log10E()
+	assert.NoError(t, err)
+	assert.Equal(t, "3e0", a.String())
+
+	_, err = Gimel{}.ExpE()
+	assert.Equal(t, &OpError{"exp", ErrPrecision}, err)
+	_, err = G(false, big.NewInt(1), big.NewInt(0), big.NewInt(0)).LnE()
+	assert.Equal(t, &OpError{"ln", ErrPrecision}, err)
+}
+
+func TestGimel_Pow(t *testing.T) {
+	assert.Equal(t, "1.024e3", gen(false, 2, 0).Pow(gen(false, 1, 1), nil).String())
+	assert.Equal(t, "-1.25e2", gen(true, 5, 0).Pow(gen(false, 3, 0), nil).String())
+	assert.Equal(t, "1.2345e8", gen(false, 11111, 4).Pow(gen(false, 2, 0), nil).String())
+	assert.Equal(t, "2.5e-1", gen(false, 2, 0).Pow(gen(true, 2, 0), nil).String())
+	assert.Equal(t, "1e0", gen(false, 2, 0).Pow(gen(false, 0, 0), nil).String())
+
+	m := gen(false, 5, 0)
+	assert.Equal(t, "1e0", gen(false, 3, 0).Pow(gen(false, 4, 0), &m).String())
+	assert.Equal(t, "2e0", gen(false, 3, 0).Pow(gen(true, 1, 0), &m).String())
+	assert.True(t, gen(false, 15, 0).Pow(gen(false, 2, 0), nil).IsNaN())
+
+	_, err := gen(false, 15, 0).PowE(gen(false, 2, 0), nil)
+	assert.Equal(t, &OpError{"pow", ErrDomain}, err)
+	_, err = gen(false, 0, 0).PowE(gen(true, 2, 0), nil)
+	assert.Equal(t, &OpError{"pow", ErrDivByZero}, err)
+	m = gen(false, 0, 0)
+	_, err = gen(false, 2, 0).PowE(gen(false, 2, 0), &m)
+	assert.Equal(t, &OpError{"pow", ErrDivByZero}, err)
+	m = gen(false, 4, 0)
+	_, err = gen(false, 2, 0).PowE(gen(true, 1, 0), &m)
+	assert.Equal(t, &OpError{"pow", ErrDomain}, err)
+}
+
+func TestGimel_ExpLarge(t *testing.T) {
+	assert.Equal(t, "1.9701e434", gen(false, 1, 3).Exp().String())
+	assert.Equal(t, "5.076e-435", gen(true, 1, 3).Exp().String())
+	assert.Equal(t, "3.6788e-1", gen(true, 1, 0).Exp().String())
+}
+
+func TestFromBigInt(t *testing.T) {
+	a, ok := FromBigInt(big.NewInt(1234567), prec)
+	assert.True(t, ok)
+	assert.Equal(t, "1.2346e6", a.String())
+	a, ok = FromBigInt(big.NewInt(-100), prec)
+	assert.True(t, ok)
+	assert.Equal(t, "-1e2", a.String())
+}
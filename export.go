@@ -6,7 +6,11 @@ import (
 )
 
 // BigInt returns the big.Int representing the full Gimel number
+// nil is returned for infinities and NaN
 func (g Gimel) BigInt() *big.Int {
+	if g.form != finite {
+		return nil
+	}
 	if g.digits.Sign() == 0 {
 		return big.NewInt(0)
 	}
@@ -14,20 +18,65 @@ func (g Gimel) BigInt() *big.Int {
 	c.Sub(g.exp, g.prec)
 	c.Add(&c, oneValue)
 	var d big.Int
-	d.Exp(tenValue, &c, nil)
-	d.Mul(&d, g.digits)
+	d.Exp(tenValue, new(big.Int).Abs(&c), nil)
+	if c.Sign() == -1 {
+		// the fractional digits are truncated
+		d.Quo(g.digits, &d)
+	} else {
+		d.Mul(&d, g.digits)
+	}
 	if g.neg {
 		d.Neg(&d)
 	}
 	return &d
 }
 
+// scaledDigits is an internal function to get the absolute value of g*10^scale rounded to an integer
+// the rounding mode of g is used to discard digits, if limit isn't 0 then false is returned
+// without scaling when the integer has more than limit digits before rounding
+func (g Gimel) scaledDigits(scale, limit int64) (*big.Int, bool) {
+	n := new(big.Int).Set(g.digits)
+	if n.Sign() == 0 {
+		return n, true
+	}
+
+	// the leading digit of g*10^scale is at 10^(exp+scale)
+	if limit != 0 && new(big.Int).Add(g.exp, big.NewInt(scale)).Cmp(big.NewInt(limit)) != -1 {
+		return nil, false
+	}
+	u := g.unitExp()
+	u.Add(u, big.NewInt(scale))
+	if u.Sign() == -1 {
+		// discarding more than prec+1 digits rounds the same way as discarding prec+1
+		u.Neg(u)
+		if p := new(big.Int).Add(g.prec, oneValue); u.Cmp(p) == 1 {
+			u = p
+		}
+		g.mode.roundDigits(g.neg, n, u)
+	} else {
+		n.Mul(n, new(big.Int).Exp(tenValue, u, nil))
+	}
+	return n, true
+}
+
+// Export returns the text representation of the Gimel number using a Format
+// An empty string is returned if the format is not registered
+func (g Gimel) Export(f Format) string {
+	if fn := lookupPrinter(f); fn != nil {
+		return fn(g)
+	}
+	return ""
+}
+
 // String is just an alias for TextE for the Stringer interface
 func (g Gimel) String() string { return g.TextE() }
 
 // TextE returns the scientific representation of the Gimel number
 // For example: 1.23e15
 func (g Gimel) TextE() string {
+	if s, ok := g.specialText(); ok {
+		return s
+	}
 	var b strings.Builder
 	if g.neg {
 		b.WriteByte('-')
@@ -35,7 +84,8 @@ func (g Gimel) TextE() string {
 	a := strings.TrimRight(g.digits.String(), "0")
 	switch len(a) {
 	case 0:
-		return "0" // end early
+		b.WriteByte('0')
+		return b.String() // end early
 	case 1:
 		b.WriteByte(a[0])
 	default:
@@ -52,58 +102,61 @@ func (g Gimel) TextE() string {
 // If the sep parameter is set to 0 then no separator is used
 // For example: 1,230,000,000,000,000
 func (g Gimel) Text(sep rune) string {
+	if s, ok := g.specialText(); ok {
+		return s
+	}
 	var b strings.Builder
 	if g.neg {
 		b.WriteByte('-')
 	}
 
+	i, f := g.fullDigits()
 	if sep == 0 {
-		g.writeFullDigits(&b)
+		b.WriteString(i)
 	} else {
-		var b2 strings.Builder
-		dec := g.writeFullDigits(&b2)
-		a := b2.String()
-		l := int64(len(a))
-
-		// decimal point check
-		var dec2 int64
-		if dec != nil {
-			dec2 = dec.Int64()
-			l += dec2
-		}
-
 		// start at digit 0th triple
-		for i := -(3 - l%3); i < l; i += 3 {
-			if i < 0 {
-				b.WriteString(a[0 : i+3])
+		l := len(i)
+		for j := -(3 - l%3) % 3; j < l; j += 3 {
+			if j < 0 {
+				b.WriteString(i[0 : j+3])
 			} else {
-				if i != 0 {
-					b.WriteRune(',')
+				if j != 0 {
+					b.WriteRune(sep)
 				}
-				b.WriteString(a[i : i+3])
+				b.WriteString(i[j : j+3])
 			}
 		}
-		if dec2 < 0 {
-			b.WriteRune('.')
-			b.WriteString(a[l:])
-		}
+	}
+	if f != "" {
+		b.WriteByte('.')
+		b.WriteString(f)
 	}
 	return b.String()
 }
 
-// writeFullDigits is an internal function to write the full digits of a Gimel number
-// this is equivalent to running Text(0) but missing the sign
-func (g Gimel) writeFullDigits(b *strings.Builder) *big.Int {
+// fullDigits is an internal function to get the integer and fractional digits of a Gimel number
+// trailing zeros are removed from the fractional digits
+func (g Gimel) fullDigits() (string, string) {
+	ds := g.digits.String()
+	if g.digits.Sign() == 0 {
+		return "0", ""
+	}
+
 	var c big.Int
 	c.Sub(g.exp, g.prec)
 	c.Add(&c, oneValue)
-	if c.Sign() == -1 {
-		b.WriteString(g.digits.String())
-		return &c
+	if c.Sign() != -1 {
+		// pad with zeros for the remaining integer digits
+		return ds + strings.Repeat("0", int(c.Int64())), ""
 	}
-	b.WriteString(g.digits.String())
-	for i := new(big.Int); i.Cmp(&c) < 0; i.Add(i, oneValue) {
-		b.WriteByte('0')
+
+	// find the position of the decimal point
+	c.Add(&c, big.NewInt(int64(len(ds))))
+	var i, f string
+	if c.Sign() != 1 {
+		i, f = "0", strings.Repeat("0", int(-c.Int64()))+ds
+	} else {
+		i, f = ds[:c.Int64()], ds[c.Int64():]
 	}
-	return nil
+	return i, strings.TrimRight(f, "0")
 }
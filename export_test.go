@@ -11,6 +11,7 @@ func TestGimel_BigInt(t *testing.T) {
 	assert.Equal(t, big.NewInt(1230000), gen(false, 123, 6).BigInt())
 	assert.Equal(t, big.NewInt(-3456000000), gen(true, 3456, 9).BigInt())
 	assert.Equal(t, big.NewInt(0), gen(true, 0, 9).BigInt())
+	assert.Equal(t, big.NewInt(12), gen(false, 123, 1).BigInt())
 }
 
 func TestGimel_TextE(t *testing.T) {
@@ -18,7 +19,7 @@ func TestGimel_TextE(t *testing.T) {
 	assert.Equal(t, "-3.456e15", gen(true, 3456, 15).TextE())
 	assert.Equal(t, "-3e15", gen(true, 3, 15).TextE())
 	assert.Equal(t, "0", gen(false, 0, 15).TextE())
-	assert.Equal(t, "0", gen(true, 0, 15).TextE())
+	assert.Equal(t, "-0", gen(true, 0, 15).TextE())
 }
 
 func TestGimel_Text(t *testing.T) {
@@ -0,0 +1,160 @@
+package gimel
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// guardDigits is the number of extra digits used for intermediate calculations
+const guardDigits = 10
+
+// floatBits is an internal function to get the number of big.Float mantissa bits
+// required to hold prec decimal digits plus some guard bits
+func floatBits(prec *big.Int) uint {
+	// log2(10) is just under 3.33 bits per decimal digit
+	return uint(prec.Int64())*333/100 + 64
+}
+
+// bigFloat is an internal function to convert the Gimel number to a big.Float
+func (g Gimel) bigFloat(bits uint) *big.Float {
+	f := new(big.Float).SetPrec(bits).SetInt(g.digits)
+	u := g.unitExp()
+	var p big.Int
+	p.Exp(tenValue, new(big.Int).Abs(u), nil)
+	q := new(big.Float).SetPrec(bits).SetInt(&p)
+	if u.Sign() == -1 {
+		f.Quo(f, q)
+	} else {
+		f.Mul(f, q)
+	}
+	if g.neg {
+		f.Neg(f)
+	}
+	return f
+}
+
+// fromBigFloat is an internal function to convert a big.Float to a Gimel number
+// the conversion keeps guard digits so the rounding mode is applied to the decimal digits
+func fromBigFloat(f *big.Float, prec *big.Int, mode RoundingMode) Gimel {
+	if f.Sign() == 0 {
+		return g2(false, big.NewInt(0), big.NewInt(0), prec, mode).normPrec()
+	}
+
+	// text is in the form -d.dddde+dd
+	s := f.Text('e', int(prec.Int64())+guardDigits)
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	m, e, _ := strings.Cut(s, "e")
+	digits, _ := new(big.Int).SetString(strings.Replace(m, ".", "", 1), 10)
+	exp, _ := strconv.ParseInt(e, 10, 64)
+	return g2(neg, digits, big.NewInt(exp), prec, mode).normPrec()
+}
+
+// atanhSeries is an internal function to calculate 2*atanh(z) = ln((1+z)/(1-z))
+// using the series 2*(z + z^3/3 + z^5/5 + ...)
+func atanhSeries(z *big.Float) *big.Float {
+	bits := z.Prec()
+	sum := new(big.Float).SetPrec(bits).Set(z)
+	if z.Sign() == 0 {
+		return sum
+	}
+	z2 := new(big.Float).SetPrec(bits).Mul(z, z)
+	term := new(big.Float).SetPrec(bits).Set(z)
+	t := new(big.Float).SetPrec(bits)
+	n := new(big.Float).SetPrec(bits)
+	limit := sum.MantExp(nil) - int(bits)
+	for i := int64(3); ; i += 2 {
+		term.Mul(term, z2)
+		t.Quo(term, n.SetInt64(i))
+		if t.Sign() == 0 || t.MantExp(nil) < limit {
+			break
+		}
+		sum.Add(sum, t)
+	}
+	return sum.Mul(sum, twoValueF)
+}
+
+// ln2Float is an internal function to calculate ln(2) = 2*atanh(1/3)
+func ln2Float(bits uint) *big.Float {
+	z := new(big.Float).SetPrec(bits).SetInt64(1)
+	return atanhSeries(z.Quo(z, new(big.Float).SetInt64(3)))
+}
+
+// lnFloat is an internal function to calculate the natural logarithm of x > 0
+//
+// x is split into m*2^k and m is moved towards 1 by taking square roots,
+// so ln(x) = 2^j*ln(m^(1/2^j)) + k*ln(2), ln(m) is then calculated with atanhSeries.
+func lnFloat(x *big.Float) *big.Float {
+	bits := x.Prec() + 32
+	m := new(big.Float).SetPrec(bits)
+	k := x.MantExp(m)
+	if k == 1 {
+		// keep x in [1, 2) intact to avoid cancellation when x is close to 1
+		m.SetMantExp(m, 1)
+		k = 0
+	}
+
+	// take square roots until m is close to 1
+	one := new(big.Float).SetPrec(bits).SetInt64(1)
+	threshold := new(big.Float).SetMantExp(one, -10)
+	d := new(big.Float).SetPrec(bits)
+	j := 0
+	for ; d.Sub(m, one).Abs(d).Cmp(threshold) == 1; j++ {
+		m.Sqrt(m)
+	}
+
+	// ln(m) = 2*atanh((m-1)/(m+1))
+	z := new(big.Float).SetPrec(bits).Sub(m, one)
+	z.Quo(z, d.Add(m, one))
+	r := atanhSeries(z)
+	r.SetMantExp(r, j)
+
+	if k != 0 {
+		l := ln2Float(bits)
+		r.Add(r, l.Mul(l, new(big.Float).SetInt64(int64(k))))
+	}
+	return r.SetPrec(x.Prec())
+}
+
+// expFloat is an internal function to calculate e^x
+//
+// x is split into k*ln(2)+r so e^x = 2^k*e^r, r is then divided by 2^j
+// so the Taylor series converges quickly and the result is squared j times.
+func expFloat(x *big.Float) *big.Float {
+	const j = 16
+	bits := x.Prec() + j + 32
+
+	// find k = round(x/ln(2)), ln(2) needs extra bits for the integer part of x
+	if e := x.MantExp(nil); e > 0 {
+		bits += uint(e)
+	}
+	l := ln2Float(bits)
+	kf := new(big.Float).SetPrec(bits).Quo(x, l)
+	kf.Add(kf, new(big.Float).SetFloat64(0.5*float64(kf.Sign())))
+	k, _ := kf.Int64()
+
+	// r = (x - k*ln(2)) / 2^j
+	r := new(big.Float).SetPrec(bits).Mul(l, new(big.Float).SetInt64(k))
+	r.Sub(x, r)
+	r.SetMantExp(r, -j)
+
+	// e^r = 1 + r + r^2/2! + r^3/3! + ...
+	sum := new(big.Float).SetPrec(bits).SetInt64(1)
+	term := new(big.Float).SetPrec(bits).SetInt64(1)
+	n := new(big.Float).SetPrec(bits)
+	for i := int64(1); ; i++ {
+		term.Mul(term, r)
+		term.Quo(term, n.SetInt64(i))
+		if term.Sign() == 0 || term.MantExp(nil) < -int(bits) {
+			break
+		}
+		sum.Add(sum, term)
+	}
+
+	for i := 0; i < j; i++ {
+		sum.Mul(sum, sum)
+	}
+	sum.SetMantExp(sum, int(k))
+	return sum.SetPrec(x.Prec())
+}
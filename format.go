@@ -0,0 +1,118 @@
+package gimel
+
+import (
+	"io"
+	"math/big"
+	"strconv"
+	"sync"
+)
+
+// ByteRuneScanner is the input used by a ScannerCallback
+type ByteRuneScanner interface {
+	io.ByteScanner
+	io.RuneScanner
+}
+
+// ScannerCallback reads a Gimel number with precision p from the scanner
+// p is nil if the number should keep all of its digits
+//
+// The scanner should stop at the first byte which is not part of the number,
+// Parse returns an error if any input is left over.
+type ScannerCallback func(r ByteRuneScanner, p *big.Int) (*Gimel, error)
+
+// PrinterCallback returns the text representation of a Gimel number
+type PrinterCallback func(g Gimel) string
+
+// DetectCallback returns true if the string looks like the format, it is used by Auto
+type DetectCallback func(s string) bool
+
+// Format is a text format used to import and export Gimel numbers
+type Format uint
+
+const (
+	Auto Format = iota
+	Numeric
+	Scientific
+)
+
+// formatEntry holds the callbacks for a registered Format
+type formatEntry struct {
+	name   string
+	detect DetectCallback
+	scan   ScannerCallback
+	print  PrinterCallback
+}
+
+var (
+	// formatLock protects formatMap and formatDetect
+	formatLock sync.RWMutex
+	// formatMap contains a map between Format and the format callbacks
+	// the Auto format uses the scientific scanner which also accepts numeric input
+	formatMap = map[Format]formatEntry{
+		Auto:       {"auto", nil, scanScientific, Gimel.TextE},
+		Numeric:    {"numeric", nil, scanNumeric, func(g Gimel) string { return g.Text(0) }},
+		Scientific: {"scientific", nil, scanScientific, Gimel.TextE},
+	}
+	// formatDetect contains the registered formats in the order they are checked by Auto
+	formatDetect []Format
+	// formatNext is the Format returned by the next call to RegisterFormat, numbers are never reused
+	formatNext = Scientific + 1
+)
+
+// RegisterFormat adds a new Format which can be used with Parse, FromString and Export
+//
+// The detect callback is checked by Auto in the order the formats were
+// registered, before falling back to the built-in scanner, it may be nil if the
+// format should never be detected. RegisterFormat panics if the name is
+// already used or the scan or print callbacks are nil.
+func RegisterFormat(name string, detect DetectCallback, scan ScannerCallback, print PrinterCallback) Format {
+	if scan == nil || print == nil {
+		panic("gimel: RegisterFormat callbacks must not be nil")
+	}
+	formatLock.Lock()
+	defer formatLock.Unlock()
+	for _, i := range formatMap {
+		if i.name == name {
+			panic("gimel: RegisterFormat called twice for " + name)
+		}
+	}
+	f := formatNext
+	formatNext++
+	formatMap[f] = formatEntry{name, detect, scan, print}
+	if detect != nil {
+		formatDetect = append(formatDetect, f)
+	}
+	return f
+}
+
+// String returns the name of the format
+func (f Format) String() string {
+	formatLock.RLock()
+	defer formatLock.RUnlock()
+	if e, ok := formatMap[f]; ok {
+		return e.name
+	}
+	return "Format(" + strconv.FormatUint(uint64(f), 10) + ")"
+}
+
+// lookupScanner is an internal function to find the scanner for a format
+// the Auto format checks the detect callbacks of the registered formats first
+func lookupScanner(f Format, s string) ScannerCallback {
+	formatLock.RLock()
+	defer formatLock.RUnlock()
+	if f == Auto {
+		for _, i := range formatDetect {
+			if e := formatMap[i]; e.detect(s) {
+				return e.scan
+			}
+		}
+	}
+	return formatMap[f].scan
+}
+
+// lookupPrinter is an internal function to find the printer for a format
+func lookupPrinter(f Format) PrinterCallback {
+	formatLock.RLock()
+	defer formatLock.RUnlock()
+	return formatMap[f].print
+}
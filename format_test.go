@@ -0,0 +1,104 @@
+package gimel
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// scanHex is a test format which reads integers in the form 0x1f
+func scanHex(r ByteRuneScanner, p *big.Int) (*Gimel, error) {
+	var s strings.Builder
+	for {
+		ch, err := r.ReadByte()
+		if err != nil {
+			break
+		}
+		s.WriteByte(ch)
+	}
+	a, ok := new(big.Int).SetString(s.String(), 0)
+	if !ok {
+		return nil, errInvalidDecimalDigit
+	}
+	if p == nil {
+		p = big.NewInt(int64(len(a.String())))
+	}
+	g, _ := FromBigInt(a, p)
+	return &g, nil
+}
+
+func printHex(g Gimel) string {
+	return "0x" + g.BigInt().Text(16)
+}
+
+func TestRegisterFormat(t *testing.T) {
+	hex := RegisterFormat("hex", func(s string) bool {
+		return strings.HasPrefix(s, "0x")
+	}, scanHex, printHex)
+	t.Cleanup(func() { unregisterFormat(hex) })
+	assert.Equal(t, "hex", hex.String())
+	assert.Equal(t, "scientific", Scientific.String())
+	assert.Equal(t, "Format(1000)", Format(1000).String())
+
+	a, ok := FromString("0xff", Auto, prec)
+	assert.True(t, ok)
+	assert.Equal(t, "2.55e2", a.String())
+	a, ok = FromString("0x1f", hex, prec)
+	assert.True(t, ok)
+	assert.Equal(t, "0x1f", a.Export(hex))
+	a, err := Parse("0x10000000")
+	assert.NoError(t, err)
+	assert.Equal(t, "268435456", a.Export(Numeric))
+
+	// other strings still use the built-in scanner
+	a, ok = FromString("12.5", Auto, prec)
+	assert.True(t, ok)
+	assert.Equal(t, "1.25e1", a.Export(Auto))
+	_, ok = FromString("12.5", hex, prec)
+	assert.False(t, ok)
+
+	assert.Panics(t, func() { RegisterFormat("hex", nil, scanHex, printHex) })
+	assert.Panics(t, func() { RegisterFormat("nil", nil, nil, printHex) })
+}
+
+// unregisterFormat is a test helper to remove a Format added by RegisterFormat
+func unregisterFormat(f Format) {
+	formatLock.Lock()
+	defer formatLock.Unlock()
+	delete(formatMap, f)
+	for i, j := range formatDetect {
+		if j == f {
+			formatDetect = append(formatDetect[:i], formatDetect[i+1:]...)
+			break
+		}
+	}
+}
+
+func TestUnregisterFormat(t *testing.T) {
+	a := RegisterFormat("a", func(s string) bool { return true }, scanHex, printHex)
+	b := RegisterFormat("b", nil, scanHex, printHex)
+	unregisterFormat(a)
+	defer unregisterFormat(b)
+
+	// the detect callback and name are removed without reusing the Format of b
+	assert.Equal(t, "Format("+strconv.Itoa(int(a))+")", a.String())
+	_, err := Parse("12.5")
+	assert.NoError(t, err)
+	c := RegisterFormat("a", nil, scanHex, printHex)
+	assert.NotEqual(t, b, c)
+	assert.Equal(t, "b", b.String())
+
+	// the highest Format isn't reused after it is removed
+	unregisterFormat(c)
+	d := RegisterFormat("d", nil, scanHex, printHex)
+	defer unregisterFormat(d)
+	assert.NotEqual(t, c, d)
+}
+
+func TestGimel_Export(t *testing.T) {
+	assert.Equal(t, "1230000", gen(false, 123, 6).Export(Numeric))
+	assert.Equal(t, "1.23e6", gen(false, 123, 6).Export(Scientific))
+	assert.Equal(t, "", gen(false, 123, 6).Export(Format(1000)))
+}
@@ -0,0 +1,236 @@
+package gimel
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Format implements fmt.Formatter
+//
+// The verbs e, E, f, F, g and G work like they do for big.Float, the default
+// precision is 6 digits for e and f, and the smallest number of digits needed
+// for g. The verbs v and s use the String representation. The digits are
+// rounded using the rounding mode of the number. The flags +, space, -, 0 and #
+// and the width are supported by every verb.
+func (g Gimel) Format(s fmt.State, verb rune) {
+	p, hasPrec := s.Precision()
+	if !hasPrec {
+		p = 6
+	}
+
+	var body string
+	switch verb {
+	case 'e', 'E':
+		body = g.formatSci(p, s.Flag('#'), verb == 'E')
+	case 'f', 'F':
+		body = g.formatFixed(p, s.Flag('#'))
+	case 'g', 'G':
+		if !hasPrec {
+			p = -1
+		}
+		body = g.formatGeneral(p, s.Flag('#'), verb == 'G')
+	case 'v', 's':
+		body = g.String()
+	default:
+		_, _ = fmt.Fprintf(s, "%%!%c(gimel.Gimel=%s)", verb, g.String())
+		return
+	}
+	g.pad(s, strings.TrimPrefix(body, "-"))
+}
+
+// pad is an internal function to write the sign and the body padded to the width of the fmt.State
+func (g Gimel) pad(s fmt.State, body string) {
+	var sign string
+	switch {
+	case g.neg:
+		sign = "-"
+	case s.Flag('+'):
+		sign = "+"
+	case s.Flag(' '):
+		sign = " "
+	}
+
+	w, _ := s.Width()
+	n := w - len(sign) - len(body)
+	switch {
+	case n <= 0:
+		_, _ = fmt.Fprint(s, sign, body)
+	case s.Flag('-'):
+		_, _ = fmt.Fprint(s, sign, body, strings.Repeat(" ", n))
+	case s.Flag('0') && g.form == finite:
+		// zeros are written after the sign
+		_, _ = fmt.Fprint(s, sign, strings.Repeat("0", n), body)
+	default:
+		_, _ = fmt.Fprint(s, strings.Repeat(" ", n), sign, body)
+	}
+}
+
+// sigDigits is an internal function to get the significant digits and the exponent of the leading digit
+// trailing zeros are removed, zero has the digits "0" and exponent 0
+func (g Gimel) sigDigits() (string, *big.Int) {
+	ds := strings.TrimRight(g.digits.String(), "0")
+	if ds == "" {
+		return "0", big.NewInt(0)
+	}
+	return ds, new(big.Int).Set(g.exp)
+}
+
+// roundSig is an internal function to round the significant digits ds to n digits
+// if n is not positive all the digits are rounded away leaving 0 or a single 1
+// the returned exponent is the exponent of the leading digit
+func (m RoundingMode) roundSig(neg bool, ds string, exp *big.Int, n int) (string, *big.Int) {
+	if n >= len(ds) {
+		return ds, exp
+	}
+	k := len(ds) - n
+	d, _ := new(big.Int).SetString(ds, 10)
+	m.roundDigits(neg, d, big.NewInt(int64(k)))
+
+	// the exponent of the last digit after rounding is exp-(len(ds)-1)+k
+	e := big.NewInt(int64(k - len(ds) + 1))
+	e.Add(e, exp)
+	if d.Sign() == 0 {
+		return "0", e
+	}
+	r := d.String()
+	e.Add(e, big.NewInt(int64(len(r)-1)))
+	if n > 0 && len(r) > n {
+		// the rounding carried into a new digit so the last digit is a zero
+		r = r[:n]
+	}
+	return r, e
+}
+
+// formatSci is an internal function to format the number with p digits after the decimal point and an exponent
+// a negative p uses all the significant digits
+func (g Gimel) formatSci(p int, sharp, upper bool) string {
+	if s, ok := g.specialText(); ok {
+		return s
+	}
+	ds, exp := g.sigDigits()
+	if p >= 0 {
+		ds, exp = g.mode.roundSig(g.neg, ds, exp, p+1)
+	} else {
+		p = len(ds) - 1
+	}
+	if ds == "0" {
+		exp.SetInt64(0)
+	}
+
+	var b strings.Builder
+	b.WriteByte(ds[0])
+	if p > 0 || sharp {
+		b.WriteByte('.')
+	}
+	b.WriteString(ds[1:])
+	b.WriteString(strings.Repeat("0", p-len(ds)+1))
+	if upper {
+		b.WriteByte('E')
+	} else {
+		b.WriteByte('e')
+	}
+	if exp.Sign() == -1 {
+		b.WriteByte('-')
+	} else {
+		b.WriteByte('+')
+	}
+	e := new(big.Int).Abs(exp).String()
+	if len(e) < 2 {
+		b.WriteByte('0')
+	}
+	b.WriteString(e)
+	return b.String()
+}
+
+// formatFixed is an internal function to format the number with p digits after the decimal point
+// a negative p uses all the significant digits
+func (g Gimel) formatFixed(p int, sharp bool) string {
+	if s, ok := g.specialText(); ok {
+		return s
+	}
+	ds, exp := g.sigDigits()
+	if p >= 0 {
+		// the digits after 10^-p are rounded away
+		n := new(big.Int).Add(exp, big.NewInt(int64(p+1)))
+		if n.Cmp(big.NewInt(int64(len(ds)))) == -1 {
+			ds, exp = g.mode.roundSig(g.neg, ds, exp, int(n.Int64()))
+		}
+	} else {
+		p = len(ds) - 1 - int(exp.Int64())
+		if p < 0 {
+			p = 0
+		}
+	}
+	return fixedDigits(ds, int(exp.Int64()), p, sharp)
+}
+
+// fixedDigits is an internal function to write the significant digits ds with the leading exponent e
+// using exactly p digits after the decimal point
+func fixedDigits(ds string, e, p int, sharp bool) string {
+	var b strings.Builder
+	if ds == "0" || e < 0 {
+		b.WriteByte('0')
+	} else if e < len(ds) {
+		b.WriteString(ds[:e+1])
+	} else {
+		b.WriteString(ds)
+		b.WriteString(strings.Repeat("0", e+1-len(ds)))
+	}
+	if p > 0 || sharp {
+		b.WriteByte('.')
+	}
+	// the digit at 10^-i is found at index e+i of ds
+	for i := 1; i <= p; i++ {
+		if j := e + i; ds != "0" && j >= 0 && j < len(ds) {
+			b.WriteByte(ds[j])
+		} else {
+			b.WriteByte('0')
+		}
+	}
+	return b.String()
+}
+
+// formatGeneral is an internal function to format the number with p significant digits
+// the exponent form is used for small and large exponents, a negative p uses all the significant digits
+func (g Gimel) formatGeneral(p int, sharp, upper bool) string {
+	if s, ok := g.specialText(); ok {
+		return s
+	}
+	ds, exp := g.sigDigits()
+	eprec := p
+	switch {
+	case p < 0 && sharp:
+		p, eprec = 6, 6
+	case p < 0:
+		eprec = 6
+	case p == 0:
+		p, eprec = 1, 1
+	}
+	if p > 0 {
+		ds, exp = g.mode.roundSig(g.neg, ds, exp, p)
+		if ds == "0" {
+			exp.SetInt64(0)
+		}
+	}
+	if !sharp {
+		ds = strings.TrimRight(ds, "0")
+		if ds == "" {
+			ds = "0"
+		}
+		p = len(ds)
+	}
+
+	if exp.Cmp(big.NewInt(-4)) == -1 || exp.Cmp(big.NewInt(int64(eprec))) != -1 {
+		r := g.Clone()
+		r.digits, _ = new(big.Int).SetString(ds, 10)
+		r.exp = exp
+		return r.formatSci(p-1, sharp, upper)
+	}
+	e := int(exp.Int64())
+	f := p - 1 - e
+	if f < 0 {
+		f = 0
+	}
+	return fixedDigits(ds, e, f, sharp)
+}
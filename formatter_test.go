@@ -0,0 +1,65 @@
+package gimel
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGimel_Format(t *testing.T) {
+	a := gen(false, 12345, 3)
+	b := gen(true, 15, -3)
+	for _, i := range []struct {
+		format string
+		g      Gimel
+		s      string
+	}{
+		{"%v", a, "1.2345e3"},
+		{"%s", b, "-1.5e-3"},
+		{"%e", a, "1.234500e+03"},
+		{"%.2e", a, "1.23e+03"},
+		{"%.0e", a, "1e+03"},
+		{"%#.0e", a, "1.e+03"},
+		{"%.3E", b, "-1.500E-03"},
+		{"%e", gen(false, 0, 0), "0.000000e+00"},
+		{"%.1e", gen(false, 99999, 100), "1.0e+101"},
+		{"%f", a, "1234.500000"},
+		{"%.2f", a, "1234.50"},
+		{"%.0f", a, "1234"},
+		{"%#.0f", a, "1234."},
+		{"%.2F", b, "-0.00"},
+		{"%.3f", b, "-0.002"},
+		{"%.1f", gen(false, 96, 1), "96.0"},
+		{"%.1f", gen(false, 99999, 0), "10.0"},
+		{"%.2f", gen(false, 1, 20), "100000000000000000000.00"},
+		{"%g", a, "1234.5"},
+		{"%g", b, "-0.0015"},
+		{"%g", gen(false, 15, -5), "1.5e-05"},
+		{"%g", gen(false, 1, 6), "1e+06"},
+		{"%g", gen(false, 1, 5), "100000"},
+		{"%.3g", a, "1.23e+03"},
+		{"%.5G", gen(false, 1, 10), "1E+10"},
+		{"%#.5g", gen(false, 1, 0), "1.0000"},
+		{"%#g", a, "1234.50"},
+		{"%g", gen(false, 0, 0), "0"},
+		{"%10.2f", a, "   1234.50"},
+		{"%-10.2f|", a, "1234.50   |"},
+		{"%010.2f", b, "-000000.00"},
+		{"%+.2f", a, "+1234.50"},
+		{"% .2f", a, " 1234.50"},
+		{"%+v", a, "+1.2345e3"},
+		{"%12v", b, "     -1.5e-3"},
+		{"%f", Inf(true, prec), "-Infinity"},
+		{"%+e", Inf(false, prec), "+Infinity"},
+		{"%05g", NaN(prec), "  NaN"},
+		{"%d", a, "%!d(gimel.Gimel=1.2345e3)"},
+	} {
+		assert.Equal(t, i.s, fmt.Sprintf(i.format, i.g), i.format)
+	}
+
+	// the digits are rounded with the rounding mode of the number
+	assert.Equal(t, "1235", fmt.Sprintf("%.0f", a.Rounding(RoundHalfUp)))
+	assert.Equal(t, "-1235", fmt.Sprintf("%.0f", a.Neg().Rounding(RoundFloor)))
+	assert.Equal(t, "0.001", fmt.Sprintf("%.3f", gen(false, 1, -9).Rounding(RoundUp)))
+	assert.Equal(t, "-0.01", fmt.Sprintf("%.2f", gen(true, 5, -3).Rounding(RoundHalfUp)))
+}
@@ -1,7 +1,6 @@
 package gimel
 
 import (
-	"fmt"
 	"math/big"
 	"strings"
 )
@@ -43,6 +42,8 @@ type Gimel struct {
 	exp    *big.Int
 	prec   *big.Int
 	p10p   *big.Int
+	mode   RoundingMode
+	form   form
 }
 
 // G returns a normalised version of the Gimel struct
@@ -51,15 +52,15 @@ func G(neg bool, digits, exp, prec *big.Int) Gimel {
 	var p, p2 big.Int
 	p.Set(prec)
 	p2.Exp(tenValue, prec, nil)
-	return Gimel{neg, digits, exp, &p, &p2}.normPrec()
+	return Gimel{neg, digits, exp, &p, &p2, RoundHalfEven, finite}.normPrec()
 }
 
 // g2 is an internal function to return the Gimel struct with cloned precision values
-func g2(neg bool, digits, exp, prec *big.Int) Gimel {
+func g2(neg bool, digits, exp, prec *big.Int, mode RoundingMode) Gimel {
 	var p, p2 big.Int
 	p.Set(prec)
 	p2.Exp(tenValue, prec, nil)
-	return Gimel{neg, digits, exp, &p, &p2}
+	return Gimel{neg, digits, exp, &p, &p2, mode, finite}
 }
 
 // minBigInt is an internal function to get the minimum big int value
@@ -83,17 +84,17 @@ func (g Gimel) normPrec() Gimel {
 		b.Exp(tenValue, &a, nil)
 		g.digits.Mul(g.digits, &b)
 	case -1:
-		// if the current digits are too short then devide to line up
+		// if the current digits are too long then round to line up
 		a.Abs(&a)
-		b.Exp(tenValue, &a, nil)
-		g.digits.Div(g.digits, &b)
+		g.roundExcess(&a)
 	}
 	return g
 }
 
 // normShift is an internal function to return the normalised version of the Gimel struct
 // this is equivalent to normPrec but also shifts the exponent the same amount as the digits
-func (g Gimel) normShift() Gimel {
+// the returned Condition reports if any digits were discarded
+func (g Gimel) normShift() (Gimel, Condition) {
 	// if the sign is negative then set the negative flag and only store absolute values
 	if g.digits.Sign() == -1 {
 		g.neg = !g.neg
@@ -114,28 +115,67 @@ func (g Gimel) normShift() Gimel {
 		b.Exp(tenValue, &a, nil)
 		g.digits.Mul(g.digits, &b)
 	case -1:
-		// if the current digits are too short then divide to line up
+		// if the current digits are too long then round to line up
 		a.Abs(&a)
-		b.Exp(tenValue, &a, nil)
-		g.digits.Div(g.digits, &b)
+		return g, g.roundExcess(&a)
 	}
-	return g
+	return g, 0
+}
+
+// roundExcess is an internal function to round away the excess number of digits a
+// the returned Condition is Rounded, and Inexact if any of the discarded digits were non-zero
+func (g *Gimel) roundExcess(a *big.Int) Condition {
+	cond := Rounded
+	if g.mode.roundDigits(g.neg, g.digits, a) {
+		cond |= Inexact
+	}
+	g.roundCarry()
+	return cond
+}
+
+// roundCarry is an internal function to remove the extra digit created when rounding carries
+// 9999 rounded up to 3 digits becomes 1000 which is shifted to 100 with the exponent incremented
+func (g *Gimel) roundCarry() {
+	if g.digits.Cmp(g.p10p) < 0 {
+		return
+	}
+	g.digits.Quo(g.digits, tenValue)
+	g.exp = new(big.Int).Add(g.exp, oneValue)
 }
 
 // Norm returns the normalised version of the Gimel struct
 // this is equivalent to normPrec but also shifts the exponent the same amount as the digits
 func (g Gimel) Norm() Gimel {
-	return g.normShift().Clone()
+	a, _ := g.normShift()
+	return a.Clone()
 }
 
 // Precision returns a new Gimel struct with a different precision value
 // normPrec is called after to retain the normalised Gimel struct
 func (g Gimel) Precision(prec *big.Int) Gimel {
+	g = g.Clone()
 	g.prec = new(big.Int).Set(prec)
 	g.p10p = new(big.Int).Exp(tenValue, prec, nil)
+	if g.form != finite {
+		return g
+	}
 	return g.normPrec()
 }
 
+// round is an internal function to round the Gimel number to prec digits
+func (g Gimel) round(prec *big.Int, mode RoundingMode) (Gimel, Condition) {
+	if r, cond, ok := nanOperand(prec, mode, g); ok {
+		return r, cond
+	}
+	if g.form == inf {
+		return infResult(g.neg, prec, mode), 0
+	}
+	exp := g.unitExp()
+	exp.Add(exp, prec)
+	exp.Sub(exp, oneValue)
+	return g2(g.neg, new(big.Int).Set(g.digits), exp, prec, mode).normShift()
+}
+
 // Clone returns a clone of the Gimel struct
 func (g Gimel) Clone() Gimel {
 	return Gimel{
@@ -144,6 +184,8 @@ func (g Gimel) Clone() Gimel {
 		(&big.Int{}).Set(g.exp),
 		new(big.Int).Set(g.prec),
 		new(big.Int).Set(g.p10p),
+		g.mode,
+		g.form,
 	}
 }
 
@@ -168,43 +210,80 @@ func (g Gimel) Neg() Gimel {
 //	0 if g == o
 //
 // +1 if g >  o
-func (g Gimel) Cmp(o Gimel) (r int) {
+//
+// +0 and -0 are equal. Like cmp.Compare a NaN is less than any other value
+// and equal to another NaN, use the comparison aliases for IEEE 754 semantics.
+func (g Gimel) Cmp(o Gimel) int {
+	gn, on := g.IsNaN(), o.IsNaN()
 	switch {
-	case g == o:
-		// do nothing
-	case g.neg == o.neg:
-		r = g.exp.Cmp(o.exp)
-		if r == 0 {
-			r = g.digits.Cmp(o.digits)
-		}
-		if g.neg {
-			r = -r
-		}
-	case g.neg:
-		r = -1
-	default:
-		r = 1
+	case gn && on:
+		return 0
+	case gn:
+		return -1
+	case on:
+		return 1
 	}
-	return
+
+	gs, os := g.Sign(), o.Sign()
+	switch {
+	case gs < os:
+		return -1
+	case gs > os:
+		return 1
+	case gs == 0:
+		return 0
+	}
+	return gs * g.cmpAbs(o)
 }
 
+// cmpAbs is an internal function to compare the absolute values of two non-zero numbers
+func (g Gimel) cmpAbs(o Gimel) int {
+	switch {
+	case g.form == inf && o.form == inf:
+		return 0
+	case g.form == inf:
+		return 1
+	case o.form == inf:
+		return -1
+	}
+	if r := g.exp.Cmp(o.exp); r != 0 {
+		return r
+	}
+
+	// line up the digits if the precision is different
+	d1, d2 := g.digits, o.digits
+	var a, b big.Int
+	switch g.prec.Cmp(o.prec) {
+	case 1:
+		a.Sub(g.prec, o.prec)
+		d2 = b.Mul(d2, a.Exp(tenValue, &a, nil))
+	case -1:
+		a.Sub(o.prec, g.prec)
+		d1 = b.Mul(d1, a.Exp(tenValue, &a, nil))
+	}
+	return d1.Cmp(d2)
+}
+
+// unordered is an internal function to check if either number is NaN
+func (g Gimel) unordered(o Gimel) bool { return g.IsNaN() || o.IsNaN() }
+
 // Gt is an alias for g > o
-func (g Gimel) Gt(o Gimel) bool { return g.Cmp(o) == 1 }
+func (g Gimel) Gt(o Gimel) bool { return !g.unordered(o) && g.Cmp(o) == 1 }
 
 // Gte is an alias for g >= o
-func (g Gimel) Gte(o Gimel) bool { return g.Cmp(o) != -1 }
+func (g Gimel) Gte(o Gimel) bool { return !g.unordered(o) && g.Cmp(o) != -1 }
 
 // Lt is an alias for g < o
-func (g Gimel) Lt(o Gimel) bool { return g.Cmp(o) == -1 }
+func (g Gimel) Lt(o Gimel) bool { return !g.unordered(o) && g.Cmp(o) == -1 }
 
 // Lte is an alias for g <= o
-func (g Gimel) Lte(o Gimel) bool { return g.Cmp(o) != 1 }
+func (g Gimel) Lte(o Gimel) bool { return !g.unordered(o) && g.Cmp(o) != 1 }
 
 // Eq is an alias for g == o
-func (g Gimel) Eq(o Gimel) bool { return g.Cmp(o) == 0 }
+func (g Gimel) Eq(o Gimel) bool { return !g.unordered(o) && g.Cmp(o) == 0 }
 
 // Neq is an alias for g != o
-func (g Gimel) Neq(o Gimel) bool { return g.Cmp(o) != 0 }
+func (g Gimel) Neq(o Gimel) bool { return !g.Eq(o) }
 
 // Min returns a clone of the minimum value
 func (g Gimel) Min(o Gimel) Gimel {
@@ -224,74 +303,116 @@ func (g Gimel) Max(o Gimel) Gimel {
 	}
 }
 
-// maxMin outputs the maximum, minimum and a boolean defining if the values were swapped
-func (g Gimel) maxMin(o Gimel) (Gimel, Gimel, bool) {
-	if g.Gt(o) {
-		return g.Clone(), o.Clone(), false
-	} else {
-		return o.Clone(), g.Clone(), true
-	}
-}
-
 // IsPos returns true if the sign is positive
 func (g Gimel) IsPos() bool { return !g.neg }
 
 // IsNeg returns true if the sign is negative
 func (g Gimel) IsNeg() bool { return g.neg }
 
-// shiftToLineUpDigits is an internal function to shift the digits to line up for add/subtract operations
-func (g Gimel) shiftToLineUpDigits(o Gimel) (d1, d2, exp, prec *big.Int) {
-	prec = new(big.Int).Set(minBigInt(g.prec, o.prec))
+// unitExp is an internal function to get the exponent of the least significant digit
+func (g Gimel) unitExp() *big.Int {
+	var u big.Int
+	u.Sub(g.exp, g.prec)
+	u.Add(&u, oneValue)
+	return &u
+}
 
-	// find the difference between the exponents in max - min order
-	m1, m2, swapped := g.maxMin(o)
-	var a big.Int
-	a.Sub(m1.exp, m2.exp)
-	if a.CmpAbs(prec) == 1 {
-		d1 = m1.digits
-		d2 = zeroValue
-		exp = m1.exp
-		return
+// signedDigits is an internal function to get a copy of the digits with the sign applied
+func (g Gimel) signedDigits() *big.Int {
+	d := new(big.Int).Set(g.digits)
+	if g.neg {
+		d.Neg(d)
 	}
+	return d
+}
 
-	// make pow10 multiplier to shift bigger number left to align digits
-	var a3 big.Int
-	a3.Exp(tenValue, &a, nil)
-
-	// perform pow10 multiply
-	d1, d2 = new(big.Int), new(big.Int)
-	d1.Mul(m1.digits, &a3)
-	d2.Set(m2.digits)
+// sticky is an internal function to replace g with a single sticky digit when it is too small
+// to change the sum g+o other than by rounding, this avoids huge shifts for distant exponents
+func (g Gimel) sticky(o Gimel, prec *big.Int) Gimel {
+	if g.digits.Sign() == 0 || o.digits.Sign() == 0 {
+		return g
+	}
 
-	// flip digits to negative
-	if m1.neg {
-		d1.Neg(d1)
+	// m is the lowest digit position which can affect the rounded sum
+	m := new(big.Int).Sub(o.exp, prec)
+	m.Sub(m, oneValue)
+	if u := o.unitExp(); u.Cmp(m) < 0 {
+		m = u
 	}
-	if m2.neg {
-		d2.Neg(d2)
+	if g.exp.Cmp(m) >= 0 {
+		return g
 	}
 
-	// swap the numbers back to the original order
-	// this makes sure subtraction is called correctly
-	if swapped {
-		d1, d2 = d2, d1
+	// a single digit below m keeps the sign and inexactness for rounding
+	m.Sub(m, oneValue)
+	return g2(g.neg, big.NewInt(1), m, oneValue, g.mode)
+}
+
+// shiftToLineUpDigits is an internal function to shift the digits to line up for add/subtract operations
+func (g Gimel) shiftToLineUpDigits(o Gimel, prec *big.Int) (d1, d2, exp *big.Int) {
+	g, o = g.sticky(o, prec), o.sticky(g, prec)
+
+	// find the exponent of the least significant digit for both numbers
+	u1, u2 := g.unitExp(), o.unitExp()
+	switch {
+	case g.digits.Sign() == 0:
+		u1 = u2
+	case o.digits.Sign() == 0:
+		u2 = u1
 	}
+	d1, d2 = g.signedDigits(), o.signedDigits()
 
-	// add to the exponent for normShift to calculate later
-	exp = new(big.Int)
-	exp.Set(m2.exp)
+	// make pow10 multiplier to shift the number with the higher unit left to align digits
+	var a, a3 big.Int
+	switch u1.Cmp(u2) {
+	case 1:
+		a.Sub(u1, u2)
+		a3.Exp(tenValue, &a, nil)
+		d1.Mul(d1, &a3)
+		u1 = u2
+	case -1:
+		a.Sub(u2, u1)
+		a3.Exp(tenValue, &a, nil)
+		d2.Mul(d2, &a3)
+	}
+
+	// convert the unit back to an exponent for normShift to calculate later
+	exp = new(big.Int).Add(u1, prec)
+	exp.Sub(exp, oneValue)
 	return
 }
 
 // Add returns the sum g+o
 func (g Gimel) Add(o Gimel) Gimel {
-	var a big.Int
-	d1, d2, exp, prec := g.shiftToLineUpDigits(o)
-	if d2.Sign() == 0 {
-		return g2(false, d1, exp, prec).normShift()
+	a, _ := g.add(o, minBigInt(g.prec, o.prec), g.mode)
+	return a
+}
+
+// AddE returns the sum g+o, or an error if the operands are invalid
+func (g Gimel) AddE(o Gimel) (Gimel, error) {
+	return binaryE("add", g, o, g.add)
+}
+
+// add is an internal function to return the sum g+o rounded to prec digits
+func (g Gimel) add(o Gimel, prec *big.Int, mode RoundingMode) (Gimel, Condition) {
+	if r, cond, ok := nanOperand(prec, mode, g, o); ok {
+		return r, cond
 	}
-	a.Add(d1, d2)
-	return g2(false, &a, exp, prec).normShift()
+	switch {
+	case g.form == inf && o.form == inf && g.neg != o.neg:
+		return nanResult(prec, mode)
+	case g.form == inf:
+		return infResult(g.neg, prec, mode), 0
+	case o.form == inf:
+		return infResult(o.neg, prec, mode), 0
+	}
+
+	d1, d2, exp := g.shiftToLineUpDigits(o, prec)
+	d1.Add(d1, d2)
+	if d1.Sign() == 0 {
+		return g2(g.zeroSumSign(o, mode), d1, exp, prec, mode).normShift()
+	}
+	return g2(false, d1, exp, prec, mode).normShift()
 }
 
 // Sub returns the difference g-o
@@ -299,96 +420,223 @@ func (g Gimel) Sub(o Gimel) Gimel {
 	return g.Add(o.Neg()) // yes this works
 }
 
+// SubE returns the difference g-o, or an error if the operands are invalid
+func (g Gimel) SubE(o Gimel) (Gimel, error) {
+	return binaryE("sub", g, o, func(o Gimel, prec *big.Int, mode RoundingMode) (Gimel, Condition) {
+		return g.add(o.Neg(), prec, mode)
+	})
+}
+
 // Mul returns the product g*o
 func (g Gimel) Mul(o Gimel) Gimel {
-	prec := new(big.Int).Set(minBigInt(g.prec, o.prec))
+	a, _ := g.mul(o, minBigInt(g.prec, o.prec), g.mode)
+	return a
+}
+
+// MulE returns the product g*o, or an error if the operands are invalid
+func (g Gimel) MulE(o Gimel) (Gimel, error) {
+	return binaryE("mul", g, o, g.mul)
+}
+
+// mul is an internal function to return the product g*o rounded to prec digits
+func (g Gimel) mul(o Gimel, prec *big.Int, mode RoundingMode) (Gimel, Condition) {
+	if r, cond, ok := nanOperand(prec, mode, g, o); ok {
+		return r, cond
+	}
+	if g.form == inf || o.form == inf {
+		// Inf * 0 is undefined
+		if g.IsZero() || o.IsZero() {
+			return nanResult(prec, mode)
+		}
+		return infResult(g.neg != o.neg, prec, mode), 0
+	}
 
 	// multiply the digits
 	var a big.Int
 	a.Mul(g.digits, o.digits)
 
-	// sum exponents
+	// sum the units of the least significant digits
 	var b big.Int
-	b.Add(g.exp, o.exp)
+	b.Add(g.unitExp(), o.unitExp())
 
 	// shift the exponent to account for the weird shift of the digits
-	b.Sub(&b, prec)
-	b.Add(&b, oneValue)
-	return g2(g.neg != o.neg, &a, &b, prec).normShift()
+	b.Add(&b, prec)
+	b.Sub(&b, oneValue)
+	return g2(g.neg != o.neg, &a, &b, prec, mode).normShift()
 }
 
 // Div returns the quotient g/o
 func (g Gimel) Div(o Gimel) Gimel {
-	prec := new(big.Int).Set(minBigInt(g.prec, o.prec))
-	p10p := new(big.Int).Set(minBigInt(g.p10p, o.p10p))
+	a, _ := g.div(o, minBigInt(g.prec, o.prec), g.mode)
+	return a
+}
 
-	// multiply bigger number by 10^prec to give space for full integer division
-	var a big.Int
-	a.Mul(g.digits, p10p)
-	a.Div(&a, o.digits)
+// DivE returns the quotient g/o, or an error if the operands are invalid
+//
+// ErrDivByZero is returned if o is zero.
+func (g Gimel) DivE(o Gimel) (Gimel, error) {
+	return binaryE("div", g, o, g.div)
+}
 
-	// subtract the exponents
+// div is an internal function to return the quotient g/o rounded to prec digits
+func (g Gimel) div(o Gimel, prec *big.Int, mode RoundingMode) (Gimel, Condition) {
+	if r, cond, ok := nanOperand(prec, mode, g, o); ok {
+		return r, cond
+	}
+	neg := g.neg != o.neg
+	switch {
+	case g.form == inf && o.form == inf:
+		return nanResult(prec, mode)
+	case g.form == inf:
+		return infResult(neg, prec, mode), 0
+	case o.form == inf:
+		return G(neg, big.NewInt(0), big.NewInt(0), prec).Rounding(mode), 0
+	case o.IsZero():
+		// 0/0 is undefined, otherwise dividing by zero is infinite
+		if g.IsZero() {
+			return nanResult(prec, mode)
+		}
+		return infResult(neg, prec, mode), DivisionByZero
+	}
+
+	// multiply the dividend by 10^k to give space for the full precision and a rounding digit
+	var k big.Int
+	k.Add(prec, twoValue)
+	k.Add(&k, o.prec)
+	k.Sub(&k, g.prec)
+	if k.Sign() == -1 {
+		k.SetInt64(0)
+	}
+	var a, r big.Int
+	a.Exp(tenValue, &k, nil)
+	a.Mul(&a, g.digits)
+	a.QuoRem(&a, o.digits, &r)
+
+	// append a sticky digit so rounding knows a remainder was discarded
+	if r.Sign() != 0 {
+		a.Mul(&a, tenValue)
+		a.Add(&a, oneValue)
+		k.Add(&k, oneValue)
+	}
+
+	// subtract the units and the scale of the dividend
 	var b big.Int
-	b.Sub(g.exp, o.exp)
+	b.Sub(g.unitExp(), o.unitExp())
+	b.Sub(&b, &k)
+	b.Add(&b, prec)
 	b.Sub(&b, oneValue)
-	return g2(g.neg != o.neg, &a, &b, prec).normShift()
+	return g2(neg, &a, &b, prec, mode).normShift()
 }
 
 // Ln returns the natural logarithm. (log base e)
 //
-// This uses the Taylor series expansion of ln(x).
-//
-// The precision of the result is the same as the precision of the input,
-// with a max of the precision of Euler's number.
+// This uses the Taylor series expansion of atanh after reducing the input
+// towards 1, see lnFloat for the details.
 //
-// https://stackoverflow.com/questions/27179674/examples-of-log-algorithm-using-arbitrary-precision-maths
+// The precision of the result is the same as the precision of the input.
+// The logarithm of a negative number is NaN and the logarithm of zero is -Inf.
 func (g Gimel) Ln() Gimel {
-	if g.neg {
-		panic("Cannot take ln of negative Gimel number")
+	a, _ := g.ln(g.prec, g.mode)
+	return a
+}
+
+// LnE returns the natural logarithm, or an error if the operand is invalid
+//
+// ErrDomain is returned for negative numbers and ErrDivByZero for zero.
+func (g Gimel) LnE() (Gimel, error) {
+	return unaryE("ln", g, g.ln)
+}
+
+// ln is an internal function to return the natural logarithm rounded to prec digits
+// the result is inexact unless g is 1
+func (g Gimel) ln(prec *big.Int, mode RoundingMode) (Gimel, Condition) {
+	if r, cond, ok := nanOperand(prec, mode, g); ok {
+		return r, cond
+	}
+	switch {
+	case g.IsZero():
+		return infResult(true, prec, mode), DivisionByZero
+	case g.neg:
+		return nanResult(prec, mode)
+	case g.form == inf:
+		return infResult(false, prec, mode), 0
+	case g.isOne():
+		return fromBigFloat(new(big.Float), prec, mode), 0
 	}
+	return fromBigFloat(lnFloat(g.bigFloat(floatBits(prec))), prec, mode), Inexact | Rounded
+}
 
-	fmt.Println("g:", g.String())
-	fmt.Println("g:", g.Text(0))
+// Log returns the logarithm using a base.
+//
+// This uses ln(g) / ln(base) internally
+func (g Gimel) Log(base Gimel) Gimel {
+	a, _ := g.log(base, g.prec, g.mode)
+	return a
+}
 
-	var (
-		y = new(big.Float).SetInt(g.BigInt())
-		x = new(big.Float).Quo(new(big.Float).Sub(y, oneValueF), new(big.Float).Add(y, oneValueF))
-		z = new(big.Float).Mul(x, x)
-		L = new(big.Float).Set(zeroValueF)
-		N = new(big.Float).SetInt(g.prec)
-	)
+// LogE returns the logarithm using a base, or an error if the operands are invalid
+func (g Gimel) LogE(base Gimel) (Gimel, error) {
+	return binaryE("log", g, base, g.log)
+}
 
-	fmt.Printf("y: %s, x: %s, z: %s, L: %s, N: %s\n", y, x, z, L, N)
-	fmt.Println(x.Cmp(N))
+// log is an internal function to return the logarithm using a base rounded to prec digits
+// both logarithms are calculated with guard digits so only the quotient is rounded
+// the result is exact if g is 1 or if g is an integer power of the base
+func (g Gimel) log(base Gimel, prec *big.Int, mode RoundingMode) (Gimel, Condition) {
+	if r, cond, ok := nanOperand(prec, mode, g, base); ok {
+		return r, cond
+	}
+	p := new(big.Int).Add(prec, big.NewInt(guardDigits))
+	a, c1 := g.ln(p, RoundDown)
+	b, c2 := base.ln(p, RoundDown)
+	r, c3 := a.div(b, prec, mode)
+
+	// the rounding of ln(base) only matters if ln(g) is not exactly zero
+	if c1&Inexact == 0 {
+		c2 &^= Inexact | Rounded
+	}
+	cond := c1 | c2 | c3
 
-	for k := big.NewFloat(1); x.Cmp(N) == 1; k.Add(k, twoValueF) {
-		t := new(big.Float).Quo(new(big.Float).Mul(twoValueF, x), k)
-		L = L.Add(L, t)
-		x = x.Mul(x, z)
-		//fmt.Printf("t: %s, L: %s, x: %s, z: %s, N: %s\n", t, L, x, z, N)
+	// the quotient of two inexact logarithms is exact if base^r is g
+	if cond&Inexact != 0 && r.IsInt() && g.isPower(base, r.BigInt()) {
+		cond &^= Inexact | Rounded
 	}
+	return r, cond
+}
 
-	fmt.Println(L)
+// isPower is an internal function to check if g is exactly base^k for positive finite numbers
+// the power is only calculated if it could be the same size as g
+func (g Gimel) isPower(base Gimel, k *big.Int) bool {
+	if g.form != finite || base.form != finite || g.neg || base.neg || g.IsZero() || base.IsZero() {
+		return false
+	}
+	x, b := g.rat(), base.rat()
+	if k.Sign() == -1 {
+		b.Inv(b)
+	}
+	n := new(big.Int).Abs(k)
 
-	var M big.Int
-	L.Int(&M)
-	a, ok := FromBigInt(&M, g.prec)
-	if !ok {
-		panic("failed to parse big int")
+	// every power of b adds at least this many bits to the numerator and denominator
+	bits := big.NewInt(int64(b.Num().BitLen() + b.Denom().BitLen() - 2))
+	if bits.Mul(bits, n).Cmp(big.NewInt(int64(x.Num().BitLen()+x.Denom().BitLen()))) == 1 {
+		return false
 	}
-	return a
+	num := new(big.Int).Exp(b.Num(), n, nil)
+	return new(big.Rat).SetFrac(num, new(big.Int).Exp(b.Denom(), n, nil)).Cmp(x) == 0
 }
 
-// Log returns the logarithm using a base.
-//
-// This uses ln(g) / ln(base) internally
-func (g Gimel) Log(base Gimel) Gimel {
+// rat is an internal function to get the exact value of a finite Gimel number as a big.Rat
+func (g Gimel) rat() *big.Rat {
+	d := new(big.Int).Set(g.digits)
 	if g.neg {
-		panic("Cannot take log of negative Gimel number")
+		d.Neg(d)
+	}
+	u := g.unitExp()
+	p := new(big.Int).Exp(tenValue, new(big.Int).Abs(u), nil)
+	if u.Sign() == -1 {
+		return new(big.Rat).SetFrac(d, p)
 	}
-	fmt.Println(g.Ln())
-	fmt.Println(base.Ln())
-	return g.Ln().Div(base.Ln())
+	return new(big.Rat).SetInt(d.Mul(d, p))
 }
 
 // Log10 returns the logarithm with base 10. Alias for Log(10)
@@ -396,8 +644,21 @@ func (g Gimel) Log10() Gimel {
 	return g.Log(G(false, big.NewInt(1), big.NewInt(1), g.prec))
 }
 
+// Log10E returns the logarithm with base 10, or an error if the operand is invalid
+func (g Gimel) Log10E() (Gimel, error) {
+	return unaryE("log10", g, func(prec *big.Int, mode RoundingMode) (Gimel, Condition) {
+		return g.log(G(false, big.NewInt(1), big.NewInt(1), prec), prec, mode)
+	})
+}
+
 // IsInt returns true if the number is an integer (non-decimal)
 func (g Gimel) IsInt() bool {
+	if g.form != finite {
+		return false
+	}
+	if g.digits.Sign() == 0 {
+		return true
+	}
 	var l big.Int
 	l.Sub(g.exp, g.prec)
 	l.Add(&l, oneValue)
@@ -410,7 +671,7 @@ func (g Gimel) IsInt() bool {
 	ds := g.digits.String()
 	dl := len(strings.TrimRight(ds, "0"))
 	l.Add(&l, g.prec)
-	if l.Cmp(big.NewInt(int64(dl))) == 0 {
+	if l.Cmp(big.NewInt(int64(dl))) != -1 {
 		return true
 	}
 	return false
@@ -418,6 +679,9 @@ func (g Gimel) IsInt() bool {
 
 // IsEven returns true if the number is even
 func (g Gimel) IsEven() bool {
+	if g.form != finite {
+		return false
+	}
 	var l big.Int
 	l.Sub(g.exp, g.prec)
 	l.Add(&l, oneValue)
@@ -445,19 +709,117 @@ func (g Gimel) IsEven() bool {
 }
 
 // Pow returns g^e mod m, with precision of g.
+//
+// All the operands must be integers, the modulus may be nil. Without a
+// modulus a negative exponent returns 1/g^-e. Invalid operands return NaN.
 func (g Gimel) Pow(e Gimel, m *Gimel) Gimel {
-	var result, mod *big.Int
+	a, _ := g.pow(e, m, g.prec, g.mode)
+	return a
+}
+
+// PowE returns g^e mod m, or an error if the operands are invalid
+func (g Gimel) PowE(e Gimel, m *Gimel) (Gimel, error) {
+	ops := []Gimel{g, e}
+	if m != nil {
+		ops = append(ops, *m)
+	}
+	if err := checkPrec("pow", ops...); err != nil {
+		return Gimel{}, err
+	}
+	r, cond := g.pow(e, m, g.prec, g.mode)
+	return r, condErr("pow", r, cond)
+}
+
+// pow is an internal function to return g^e mod m rounded to prec digits
+func (g Gimel) pow(e Gimel, m *Gimel, prec *big.Int, mode RoundingMode) (Gimel, Condition) {
+	ops := []Gimel{g, e}
+	if m != nil {
+		ops = append(ops, *m)
+	}
+	if r, cond, ok := nanOperand(prec, mode, ops...); ok {
+		return r, cond
+	}
+	for _, i := range ops {
+		if !i.IsInt() {
+			return nanResult(prec, mode)
+		}
+	}
+
+	var mod *big.Int
 	if m != nil {
 		mod = m.BigInt()
+		if mod.Sign() == 0 {
+			return special(qnan, false, big.NewInt(0), prec, mode), DivisionByZero
+		}
 	}
-	result.Exp(g.BigInt(), e.BigInt(), mod)
-	a, ok := FromBigInt(result, g.prec)
-	if !ok {
-		panic("failed to parse big int")
+
+	x, y := g.BigInt(), e.BigInt()
+	if y.Sign() == -1 && mod == nil {
+		// g^-e = 1/g^e
+		if x.Sign() == 0 {
+			return infResult(g.neg, prec, mode), DivisionByZero
+		}
+		d, _ := fromInt(new(big.Int).Exp(x, y.Neg(y), nil), prec, RoundHalfEven)
+		return G(false, big.NewInt(1), big.NewInt(0), prec).div(d, prec, mode)
 	}
-	return a
+
+	// a negative exponent with a modulus requires the modular inverse
+	r := new(big.Int).Exp(x, y, mod)
+	if r == nil {
+		return nanResult(prec, mode)
+	}
+	return fromInt(r, prec, mode)
 }
 
 // Exp returns e^g where e is Euler's number
-// precision maxes out at the precision of Euler's number.
-func (g Gimel) Exp() Gimel { return Euler.Pow(g, nil) }
+//
+// The precision of the result is the same as the precision of the input.
+func (g Gimel) Exp() Gimel {
+	a, _ := g.exponential(g.prec, g.mode)
+	return a
+}
+
+// ExpE returns e^g or an error if g has no valid precision
+func (g Gimel) ExpE() (Gimel, error) {
+	return unaryE("exp", g, g.exponential)
+}
+
+// exponential is an internal function to return e^g rounded to prec digits
+// the result is inexact unless g is 0
+func (g Gimel) exponential(prec *big.Int, mode RoundingMode) (Gimel, Condition) {
+	if r, cond, ok := nanOperand(prec, mode, g); ok {
+		return r, cond
+	}
+	switch {
+	case g.form == inf && g.neg:
+		return G(false, big.NewInt(0), big.NewInt(0), prec).Rounding(mode), 0
+	case g.form == inf:
+		return infResult(false, prec, mode), 0
+	case g.digits.Sign() == 0:
+		return fromBigFloat(big.NewFloat(1), prec, mode), 0
+	}
+
+	// e^g = 10^t where t = g/ln(10) is split into an integer n and a fraction f,
+	// only e^(f*ln(10)) is calculated using floats and n is added to the exponent
+	// this keeps huge results within the exponent range of big.Float
+	bits := floatBits(prec)
+	if g.exp.Sign() == 1 {
+		bits += uint(g.exp.Int64())*333/100 + 1
+	}
+	l := lnFloat(new(big.Float).SetPrec(bits).SetInt64(10))
+	t := new(big.Float).SetPrec(bits).Quo(g.bigFloat(bits), l)
+	n, _ := t.Int(nil)
+	if t.Sign() == -1 && !t.IsInt() {
+		n.Sub(n, oneValue)
+	}
+	t.Sub(t, new(big.Float).SetInt(n))
+
+	r := fromBigFloat(expFloat(t.Mul(t, l)), prec, mode)
+	r.exp.Add(r.exp, n)
+	return r, Inexact | Rounded
+}
+
+// isOne is an internal function to check if the Gimel number is exactly 1
+func (g Gimel) isOne() bool {
+	return g.form == finite && !g.neg && g.exp.Sign() == 0 && g.digits.Cmp(new(big.Int).Quo(g.p10p, tenValue)) == 0
+}
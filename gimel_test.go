@@ -144,9 +144,10 @@ func TestGimel_Div(t *testing.T) {
 }
 
 func TestGimel_Log10(t *testing.T) {
-	assert.Equal(t, "1", gen(false, 10, 0).Log10().Text(0))
-	assert.Equal(t, "2", gen(false, 100, 0).Log10().Text(0))
-	assert.Equal(t, "3", gen(false, 1000, 0).Log10().Text(0))
+	assert.Equal(t, "1", gen(false, 1, 1).Log10().Text(0))
+	assert.Equal(t, "2", gen(false, 1, 2).Log10().Text(0))
+	assert.Equal(t, "3", gen(false, 1, 3).Log10().Text(0))
+	assert.Equal(t, "0", gen(false, 1, 0).Log10().Text(0))
 }
 
 func TestGimel_IsInt(t *testing.T) {
@@ -160,6 +161,8 @@ func TestGimel_IsInt(t *testing.T) {
 	assert.False(t, gen(false, 1234, 2).IsInt())
 	assert.True(t, gen(false, 1234, 3).IsInt())
 	assert.False(t, gen(false, 11, 0).IsInt())
+	assert.True(t, gen(false, 1, 1).IsInt())
+	assert.True(t, gen(false, 0, 0).IsInt())
 }
 
 func TestGimel_IsEven(t *testing.T) {
@@ -175,5 +178,5 @@ func TestGimel_IsEven(t *testing.T) {
 
 func TestGimel_Exp(t *testing.T) {
 	assert.Equal(t, gen(false, 1, 0), gen(false, 0, 0).Exp())
-	assert.Equal(t, Euler, gen(false, 1, 0).Exp())
+	assert.Equal(t, Euler.Precision(prec), gen(false, 1, 0).Exp())
 }
@@ -0,0 +1,338 @@
+package gimel
+
+import (
+	"errors"
+	"math/big"
+)
+
+// DecimalFormat is an IEEE 754-2008 decimal interchange format
+type DecimalFormat struct {
+	bits int // storage width in bits
+	prec int // number of digits in the coefficient
+	emax int // largest exponent of the leading digit
+}
+
+var (
+	Decimal32  = DecimalFormat{32, 7, 96}
+	Decimal64  = DecimalFormat{64, 16, 384}
+	Decimal128 = DecimalFormat{128, 34, 6144}
+)
+
+// DecimalEncoding is the encoding used for the coefficient of an IEEE 754 decimal
+type DecimalEncoding byte
+
+const (
+	BID DecimalEncoding = iota // Binary Integer Decimal, the coefficient is a binary integer
+	DPD                        // Densely Packed Decimal, the coefficient is stored as 10 bit declets
+)
+
+var errInvalidIEEE754 = errors.New("gimel: invalid IEEE 754 decimal")
+
+// combBits is an internal function to get the width w of the exponent continuation,
+// the combination field has w+5 bits
+func (f DecimalFormat) combBits() uint { return uint(f.bits/16 + 4) }
+
+// trailBits is an internal function to get the width of the trailing significand field
+func (f DecimalFormat) trailBits() uint { return uint(f.bits*15/16 - 10) }
+
+// bias is an internal function to get the bias added to the exponent of the last digit
+func (f DecimalFormat) bias() int64 { return int64(f.emax + f.prec - 2) }
+
+// Context returns a Context which rounds numbers to the range of the format
+func (f DecimalFormat) Context(mode RoundingMode) *Context {
+	return &Context{
+		Prec:     big.NewInt(int64(f.prec)),
+		Rounding: mode,
+		MaxExp:   big.NewInt(int64(f.emax)),
+		MinExp:   big.NewInt(int64(1 - f.emax)),
+	}
+}
+
+// EncodeIEEE754 returns g as an IEEE 754 decimal in big-endian byte order
+//
+// The number is rounded to the format using its rounding mode, numbers which
+// are too large become infinity or the largest finite number depending on the
+// rounding mode, and small numbers become subnormal. NaN payloads keep their
+// lowest prec-1 digits.
+func EncodeIEEE754(g Gimel, f DecimalFormat, enc DecimalEncoding) ([]byte, error) {
+	if err := checkPrec("ieee754", g); err != nil {
+		return nil, err
+	}
+	w, t := f.combBits(), f.trailBits()
+
+	var comb, trail big.Int
+	switch {
+	case g.IsInf():
+		comb.SetInt64(0x1e)
+		comb.Lsh(&comb, w)
+	case g.IsNaN():
+		comb.SetInt64(0x3e)
+		if g.IsSNaN() {
+			comb.SetBit(&comb, 0, 1)
+		}
+		comb.Lsh(&comb, w-1)
+		var p big.Int
+		p.Exp(tenValue, big.NewInt(int64(f.prec-1)), nil)
+		p.Mod(g.digits, &p)
+		if enc == BID {
+			trail.Set(&p)
+		} else {
+			encodeDeclets(&trail, &p, f.prec-1)
+		}
+	default:
+		c, e := f.coefficient(g)
+		if enc == BID {
+			encodeBID(&comb, &trail, c, e, w, t)
+		} else {
+			encodeDPD(&comb, &trail, c, e, w, f.prec)
+		}
+	}
+
+	// sign | combination field | trailing significand
+	var x big.Int
+	if g.neg {
+		x.SetBit(&x, f.bits-1, 1)
+	}
+	comb.Lsh(&comb, t)
+	x.Or(&x, &comb)
+	x.Or(&x, &trail)
+	return x.FillBytes(make([]byte, f.bits/8)), nil
+}
+
+// coefficient is an internal function to round g to the format
+// the integer coefficient and the biased exponent of the last digit are returned
+func (f DecimalFormat) coefficient(g Gimel) (*big.Int, int64) {
+	r, _ := f.Context(g.mode).Round(g)
+	if r.IsInf() {
+		return nil, -1
+	}
+	c := new(big.Int).Set(r.digits)
+	q := r.unitExp()
+
+	// subnormal numbers have zeros below the smallest exponent
+	qmin := big.NewInt(-f.bias())
+	qmax := big.NewInt(int64(f.emax - f.prec + 1))
+	if q.Cmp(qmin) == -1 {
+		c.Quo(c, new(big.Int).Exp(tenValue, new(big.Int).Sub(qmin, q), nil))
+		q = qmin
+	}
+
+	// trailing zeros are removed up to the exponent of g so the quantum of g is kept
+	t := g.unitExp()
+	if t.Cmp(qmax) == 1 {
+		t = qmax
+	}
+	var r10 big.Int
+	switch {
+	case c.Sign() == 0:
+		if t.Cmp(qmin) == 1 {
+			q = t
+		}
+	default:
+		for q.Cmp(t) == -1 && r10.Mod(c, tenValue).Sign() == 0 {
+			c.Quo(c, tenValue)
+			q.Add(q, oneValue)
+		}
+	}
+	return c, q.Int64() + f.bias()
+}
+
+// encodeBID is an internal function to write the combination field and trailing significand of a finite BID number
+// an exponent of -1 means the number overflowed to infinity
+func encodeBID(comb, trail, c *big.Int, e int64, w, t uint) {
+	if e == -1 {
+		comb.Lsh(big.NewInt(0x1e), w)
+		return
+	}
+	var mask big.Int
+	mask.Lsh(oneValue, t).Sub(&mask, oneValue)
+	trail.And(c, &mask)
+	hi := new(big.Int).Rsh(c, t).Int64()
+	if hi < 8 {
+		// eeeeee hhh
+		comb.SetInt64(e<<3 | hi)
+	} else {
+		// 11 eeeeee h, the high bits of the coefficient are 100h
+		comb.SetInt64(3<<(w+3) | e<<1 | hi&1)
+	}
+}
+
+// encodeDPD is an internal function to write the combination field and trailing significand of a finite DPD number
+// an exponent of -1 means the number overflowed to infinity
+func encodeDPD(comb, trail, c *big.Int, e int64, w uint, prec int) {
+	if e == -1 {
+		comb.Lsh(big.NewInt(0x1e), w)
+		return
+	}
+	var p, r big.Int
+	p.Exp(tenValue, big.NewInt(int64(prec-1)), nil)
+	d, _ := new(big.Int).QuoRem(c, &p, &r)
+	encodeDeclets(trail, &r, prec-1)
+
+	lead, msb, cont := d.Int64(), e>>w, e&(1<<w-1)
+	if lead < 8 {
+		// ee ddd cccccc
+		comb.SetInt64(msb<<(w+3) | lead<<w | cont)
+	} else {
+		// 11 ee d cccccc, the leading digit is 100d
+		comb.SetInt64(3<<(w+3) | msb<<(w+1) | (lead&1)<<w | cont)
+	}
+}
+
+// encodeDeclets is an internal function to write the n digits of c as DPD declets
+func encodeDeclets(trail, c *big.Int, n int) {
+	s := c.String()
+	for len(s) < n {
+		s = "0" + s
+	}
+	trail.SetInt64(0)
+	for i := 0; i < n; i += 3 {
+		trail.Lsh(trail, 10)
+		d := int(s[i]-'0')*100 + int(s[i+1]-'0')*10 + int(s[i+2]-'0')
+		trail.Or(trail, big.NewInt(int64(dpdEncode(d))))
+	}
+}
+
+// dpdEncode is an internal function to encode 3 digits in a 10 bit declet
+//
+// The digits are abc, def and ghi where small digits 0-7 are 0abc and large
+// digits 8-9 are 100c, the bits are arranged depending on which digits are large.
+func dpdEncode(n int) uint16 {
+	d2, d1, d0 := uint16(n/100), uint16(n/10%10), uint16(n%10)
+	a, b, c := d2>>2&1, d2>>1&1, d2&1
+	d, e, f := d1>>2&1, d1>>1&1, d1&1
+	g, h, i := d0>>2&1, d0>>1&1, d0&1
+	bits := func(v ...uint16) uint16 {
+		var r uint16
+		for _, j := range v {
+			r = r<<1 | j
+		}
+		return r
+	}
+	switch (d2 >> 3 << 2) | (d1 >> 3 << 1) | d0>>3 {
+	case 0b000:
+		return bits(a, b, c, d, e, f, 0, g, h, i)
+	case 0b001:
+		return bits(a, b, c, d, e, f, 1, 0, 0, i)
+	case 0b010:
+		return bits(a, b, c, g, h, f, 1, 0, 1, i)
+	case 0b100:
+		return bits(g, h, c, d, e, f, 1, 1, 0, i)
+	case 0b110:
+		return bits(g, h, c, 0, 0, f, 1, 1, 1, i)
+	case 0b101:
+		return bits(d, e, c, 0, 1, f, 1, 1, 1, i)
+	case 0b011:
+		return bits(a, b, c, 1, 0, f, 1, 1, 1, i)
+	}
+	return bits(0, 0, c, 1, 1, f, 1, 1, 1, i)
+}
+
+// dpdDecode is an internal function to decode a 10 bit declet to 3 digits
+// every declet is accepted including the non-canonical encodings
+func dpdDecode(v uint16) int {
+	bit := func(n uint) int { return int(v >> n & 1) }
+	small := func(x, y, z uint) int { return bit(x)<<2 | bit(y)<<1 | bit(z) }
+	var d2, d1, d0 int
+	switch {
+	case bit(3) == 0:
+		d2, d1, d0 = small(9, 8, 7), small(6, 5, 4), small(2, 1, 0)
+	case bit(2) == 0 && bit(1) == 0:
+		d2, d1, d0 = small(9, 8, 7), small(6, 5, 4), 8|bit(0)
+	case bit(2) == 0:
+		d2, d1, d0 = small(9, 8, 7), 8|bit(4), small(6, 5, 0)
+	case bit(1) == 0:
+		d2, d1, d0 = 8|bit(7), small(6, 5, 4), small(9, 8, 0)
+	case bit(6) == 0 && bit(5) == 0:
+		d2, d1, d0 = 8|bit(7), 8|bit(4), small(9, 8, 0)
+	case bit(6) == 0:
+		d2, d1, d0 = 8|bit(7), small(9, 8, 4), 8|bit(0)
+	case bit(5) == 0:
+		d2, d1, d0 = small(9, 8, 7), 8|bit(4), 8|bit(0)
+	default:
+		d2, d1, d0 = 8|bit(7), 8|bit(4), 8|bit(0)
+	}
+	return d2*100 + d1*10 + d0
+}
+
+// decodeDeclets is an internal function to read the n digits stored as DPD declets in trail
+func decodeDeclets(trail *big.Int, n int) *big.Int {
+	c := new(big.Int)
+	var mask, v big.Int
+	mask.SetInt64(0x3ff)
+	for i := n/3 - 1; i >= 0; i-- {
+		v.Rsh(trail, uint(10*i)).And(&v, &mask)
+		c.Mul(c, big.NewInt(1000))
+		c.Add(c, big.NewInt(int64(dpdDecode(uint16(v.Int64())))))
+	}
+	return c
+}
+
+// DecodeIEEE754 returns the Gimel number from an IEEE 754 decimal in big-endian byte order
+//
+// The precision of the number is the precision of the format. Non-canonical
+// BID coefficients and NaN payloads which are too large are read as zero.
+func DecodeIEEE754(b []byte, f DecimalFormat, enc DecimalEncoding) (Gimel, error) {
+	if len(b) != f.bits/8 {
+		return Gimel{}, errInvalidIEEE754
+	}
+	w, t := f.combBits(), f.trailBits()
+	prec := big.NewInt(int64(f.prec))
+
+	x := new(big.Int).SetBytes(b)
+	neg := x.Bit(f.bits-1) == 1
+	var trail, mask big.Int
+	mask.Lsh(oneValue, t).Sub(&mask, oneValue)
+	trail.And(x, &mask)
+	mask.Lsh(oneValue, w+5).Sub(&mask, oneValue)
+	comb := new(big.Int).Rsh(x, t)
+	comb.And(comb, &mask)
+	g := comb.Int64()
+
+	var p big.Int
+	p.Exp(tenValue, big.NewInt(int64(f.prec-1)), nil)
+	switch g >> w {
+	case 0x1e:
+		return Inf(neg, prec), nil
+	case 0x1f:
+		payload := &trail
+		if enc == DPD {
+			payload = decodeDeclets(&trail, f.prec-1)
+		}
+		if payload.Cmp(&p) != -1 {
+			payload.SetInt64(0)
+		}
+		form := qnan
+		if g>>(w-1)&1 == 1 {
+			form = snan
+		}
+		return special(form, neg, payload, prec, RoundHalfEven), nil
+	}
+
+	var c *big.Int
+	var e int64
+	if enc == BID {
+		if g>>(w+3) != 3 {
+			e = g >> 3
+			c = new(big.Int).Lsh(big.NewInt(g&7), t)
+		} else {
+			e = g >> 1 & (1<<(w+2) - 1)
+			c = new(big.Int).Lsh(big.NewInt(8|g&1), t)
+		}
+		c.Or(c, &trail)
+		if c.Cmp(new(big.Int).Mul(&p, tenValue)) != -1 {
+			c.SetInt64(0)
+		}
+	} else {
+		var lead, msb int64
+		if top := g >> w; top>>3 != 3 {
+			msb, lead = top>>3, top&7
+		} else {
+			msb, lead = top>>1&3, 8|top&1
+		}
+		e = msb<<w | g&(1<<w-1)
+		c = decodeDeclets(&trail, f.prec-1)
+		c.Add(c, p.Mul(&p, big.NewInt(lead)))
+	}
+	return fromCoef(neg, c, big.NewInt(e-f.bias()), prec), nil
+}
@@ -0,0 +1,110 @@
+package gimel
+
+import (
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+)
+
+func TestDPD(t *testing.T) {
+	assert.Equal(t, uint16(0x005), dpdEncode(5))
+	assert.Equal(t, uint16(0x009), dpdEncode(9))
+	assert.Equal(t, uint16(0x0ff), dpdEncode(999))
+	assert.Equal(t, uint16(0x3d0), dpdEncode(750))
+	assert.Equal(t, uint16(0x39a), dpdEncode(790))
+	for i := 0; i < 1000; i++ {
+		assert.Equal(t, i, dpdDecode(dpdEncode(i)))
+	}
+	// non-canonical declets
+	assert.Equal(t, 999, dpdDecode(0x3ff))
+	assert.Equal(t, 888, dpdDecode(0x36e))
+}
+
+func TestEncodeIEEE754(t *testing.T) {
+	for _, i := range []struct {
+		s   string
+		f   DecimalFormat
+		bid string
+		dpd string
+	}{
+		{"1", Decimal32, "32800001", "22500001"},
+		{"1", Decimal64, "31c0000000000001", "2238000000000001"},
+		{"1", Decimal128, "30400000000000000000000000000001", "22080000000000000000000000000001"},
+		{"-7.50", Decimal64, "b1800000000002ee", "a2300000000003d0"},
+		{"9.999999999999999e384", Decimal64, "77fb86f26fc0ffff", "77fcff3fcff3fcff"},
+		{"1e-398", Decimal64, "0000000000000001", "0000000000000001"},
+		{"0", Decimal64, "31c0000000000000", "2238000000000000"},
+		{"-0", Decimal32, "b2800000", "a2500000"},
+		{"Infinity", Decimal64, "7800000000000000", "7800000000000000"},
+		{"-Infinity", Decimal32, "f8000000", "f8000000"},
+		{"NaN", Decimal64, "7c00000000000000", "7c00000000000000"},
+		{"sNaN", Decimal64, "7e00000000000000", "7e00000000000000"},
+		{"-NaN123", Decimal32, "fc00007b", "fc0000a3"},
+	} {
+		g, err := Parse(i.s)
+		assert.NoError(t, err)
+		b, err := EncodeIEEE754(g, i.f, BID)
+		assert.NoError(t, err)
+		assert.Equal(t, i.bid, hex.EncodeToString(b), i.s)
+		b, err = EncodeIEEE754(g, i.f, DPD)
+		assert.NoError(t, err)
+		assert.Equal(t, i.dpd, hex.EncodeToString(b), i.s)
+
+		for _, enc := range []DecimalEncoding{BID, DPD} {
+			b, _ = EncodeIEEE754(g, i.f, enc)
+			a, err := DecodeIEEE754(b, i.f, enc)
+			assert.NoError(t, err)
+			assert.Equal(t, g.String(), a.String(), i.s)
+			assert.Equal(t, big.NewInt(int64(i.f.prec)), a.prec)
+		}
+	}
+}
+
+func TestEncodeIEEE754_Rounding(t *testing.T) {
+	enc := func(g Gimel, f DecimalFormat) string {
+		b, err := EncodeIEEE754(g, f, BID)
+		assert.NoError(t, err)
+		a, err := DecodeIEEE754(b, f, BID)
+		assert.NoError(t, err)
+		return a.String()
+	}
+	assert.Equal(t, "3.141593e0", enc(Pi, Decimal32))
+	assert.Equal(t, "3.141592e0", enc(Pi.Rounding(RoundDown), Decimal32))
+	assert.Equal(t, "3.141592653589793e0", enc(Pi, Decimal64))
+	assert.Equal(t, "3.141592653589793238462643383279503e0", enc(Pi, Decimal128))
+
+	// overflow depends on the rounding mode
+	assert.Equal(t, "Infinity", enc(gen(false, 1, 97), Decimal32))
+	assert.Equal(t, "9.999999e96", enc(gen(false, 1, 97).Rounding(RoundDown), Decimal32))
+	assert.Equal(t, "-Infinity", enc(gen(true, 1, 97).Rounding(RoundFloor), Decimal32))
+
+	// subnormal numbers lose digits and tiny numbers round to zero
+	assert.Equal(t, "1.23e-99", enc(gen(false, 12345, -99), Decimal32))
+	assert.Equal(t, "1e-101", enc(gen(false, 1, -101), Decimal32))
+	assert.Equal(t, "0", enc(gen(false, 1, -103), Decimal32))
+	assert.Equal(t, "1e-101", enc(gen(false, 1, -103).Rounding(RoundUp), Decimal32))
+
+	// large NaN payloads keep their lowest digits
+	assert.Equal(t, "NaN234567", enc(special(qnan, false, big.NewInt(1234567), prec, RoundHalfEven), Decimal32))
+
+	_, err := EncodeIEEE754(Gimel{}, Decimal32, BID)
+	assert.Error(t, err)
+}
+
+func TestDecodeIEEE754(t *testing.T) {
+	_, err := DecodeIEEE754([]byte{0, 0}, Decimal32, BID)
+	assert.Error(t, err)
+
+	// non-canonical coefficients are zero
+	b, _ := hex.DecodeString("6cb89680")
+	a, err := DecodeIEEE754(b, Decimal32, BID)
+	assert.NoError(t, err)
+	assert.True(t, a.IsZero())
+
+	// the largest exponent with a small coefficient is still exact
+	b, _ = hex.DecodeString("5f800001")
+	a, err = DecodeIEEE754(b, Decimal32, BID)
+	assert.NoError(t, err)
+	assert.Equal(t, "1e90", a.String())
+}
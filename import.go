@@ -2,85 +2,123 @@ package gimel
 
 import (
 	"errors"
-	"fmt"
 	"io"
 	"math/big"
-	"regexp"
 	"strings"
+	"unicode/utf8"
 )
 
-type byteRuneScanner interface {
-	io.ByteScanner
-	io.RuneScanner
-}
-
-type scannerCallback func(r byteRuneScanner, p *big.Int) (*Gimel, error)
+var (
+	errInvalidDecimalDigit       = &tokenError{expected: "digit"}
+	errInvalidScientificNotation = &tokenError{expected: "exponent digit"}
+	errInvalidSpecialValue       = &tokenError{expected: "digit, Infinity or NaN"}
+	errTrailingInput             = &tokenError{expected: "end of input"}
+	errUnknownFormat             = errors.New("gimel: unknown format")
+)
 
-type formatDetectList []struct {
-	Format
-	*regexp.Regexp
+// tokenError is an internal error returned by the scanners when the next token is not the expected token
+// back is the number of bytes already consumed from the unexpected token
+type tokenError struct {
+	expected string
+	back     int
 }
 
-type Format uint
+func (e *tokenError) Error() string { return "expected " + e.expected }
 
-const (
-	Auto Format = iota
-	Numeric
-	Scientific
-)
+// ParseOption changes the behaviour of Parse
+type ParseOption func(*parseConfig)
 
-var (
-	// formatDetect contains a format, regex pair to autodetect formats
-	formatDetect = formatDetectList{
-		{Numeric, regexp.MustCompile(`[+-]?\d+`)},
-		{Scientific, regexp.MustCompile(`[+-]?\d+e\d+`)},
-	}
-	// formatMap contains a map between Format and scannerCallback functions
-	formatMap = map[Format]scannerCallback{
-		Numeric:    scanNumeric,
-		Scientific: scanScientific,
-	}
+type parseConfig struct {
+	format Format
+	prec   *big.Int
+}
 
-	errInvalidDecimalDigit       = fmt.Errorf("invalid decimal digit")
-	errInvalidScientificNotation = fmt.Errorf("invalid scientific notation")
-)
+// WithFormat sets the Format used to parse the string, the default is Auto
+func WithFormat(f Format) ParseOption {
+	return func(c *parseConfig) { c.format = f }
+}
+
+// WithPrecision sets the precision of the parsed number
+// without this option the precision is the number of digits in the string
+func WithPrecision(prec *big.Int) ParseOption {
+	return func(c *parseConfig) { c.prec = prec }
+}
 
 // FromBigInt returns the Gimel number from a big.Int with a precision
 func FromBigInt(a *big.Int, prec *big.Int) (Gimel, bool) {
-	fmt.Println(a.String())
-	return FromString(a.String(), Numeric, prec)
+	g, _ := fromInt(a, prec, RoundHalfEven)
+	return g, true
+}
+
+// fromInt is an internal function to return the Gimel number from a big.Int rounded to prec digits
+func fromInt(a *big.Int, prec *big.Int, mode RoundingMode) (Gimel, Condition) {
+	exp := new(big.Int).Sub(prec, oneValue)
+	return g2(false, new(big.Int).Set(a), exp, prec, mode).normShift()
+}
+
+// fromCoef is an internal function to return the Gimel number c*10^e rounded to prec digits
+// if prec is nil then the precision is the number of digits in c
+func fromCoef(neg bool, c, e, prec *big.Int) Gimel {
+	if prec == nil {
+		prec = big.NewInt(int64(len(new(big.Int).Abs(c).String())))
+	}
+	exp := new(big.Int).Add(e, prec)
+	exp.Sub(exp, oneValue)
+	g, _ := g2(neg, new(big.Int).Set(c), exp, prec, RoundHalfEven).normShift()
+	return g
 }
 
 // FromString returns the Gimal number from a string, Format and precision
 func FromString(s string, f Format, prec *big.Int) (Gimel, bool) {
-	if f == Auto {
-		for _, i := range formatDetect {
-			if i.MatchString(s) {
-				f = i.Format
-				break
-			}
-		}
-		if f == Auto {
-			return Gimel{}, false
+	g, err := Parse(s, WithFormat(f), WithPrecision(prec))
+	return g, err == nil
+}
+
+// Parse returns the Gimel number from a string
+//
+// The string may contain a sign, decimal digits with an optional decimal point
+// and an exponent, or the case-insensitive names Inf, Infinity, NaN and sNaN.
+// If the string is invalid the error is a *ParseError.
+func Parse(s string, opts ...ParseOption) (Gimel, error) {
+	var c parseConfig
+	for _, i := range opts {
+		i(&c)
+	}
+	fn := lookupScanner(c.format, s)
+	if fn == nil {
+		return Gimel{}, errUnknownFormat
+	}
+	if c.prec != nil && c.prec.Sign() != 1 {
+		return Gimel{}, &OpError{"parse", ErrPrecision}
+	}
+
+	r := strings.NewReader(s)
+	g, err := fn(r, c.prec)
+	if err == nil {
+		// entire content must have been consumed
+		if _, err2 := r.ReadByte(); err2 != io.EOF {
+			_ = r.UnreadByte()
+			err = errTrailingInput
 		}
 	}
-	if fn, ok := formatMap[f]; ok {
-		return setFromScanner(strings.NewReader(s), prec, fn)
+	if err != nil {
+		return Gimel{}, newParseError(s, len(s)-r.Len(), err)
 	}
-	return Gimel{}, false
+	return *g, nil
 }
 
-// setFromScanner implements FromString given an io.ByteScanner
-func setFromScanner(r byteRuneScanner, prec *big.Int, scan scannerCallback) (Gimel, bool) {
-	g, err := scan(r, prec)
-	if err != nil {
-		return Gimel{}, false
+// newParseError is an internal function to create a ParseError at the offset of the scanner
+func newParseError(s string, off int, err error) *ParseError {
+	e := &ParseError{Input: s, Offset: off, Rune: -1, Expected: err.Error()}
+	var t *tokenError
+	if errors.As(err, &t) {
+		e.Offset -= t.back
+		e.Expected = t.expected
 	}
-	// entire content must have been consumed
-	if _, err := r.ReadByte(); err != io.EOF {
-		return Gimel{}, false
+	if e.Offset < len(s) {
+		e.Rune, _ = utf8.DecodeRuneInString(s[e.Offset:])
 	}
-	return *g, true // err == io.EOF => scan consumed all content of r
+	return e
 }
 
 func scanSign(r io.ByteScanner) (neg bool, err error) {
@@ -99,6 +137,44 @@ func scanSign(r io.ByteScanner) (neg bool, err error) {
 	return
 }
 
+// scanSpecial scans the case-insensitive names of infinities and NaNs
+// the digits following a NaN are used as the payload, a nil Gimel is returned for other input
+func scanSpecial(r io.ByteScanner, neg bool, p *big.Int) (*Gimel, error) {
+	var word []byte
+	for {
+		ch, err := r.ReadByte()
+		if err != nil {
+			break
+		}
+		if (ch < 'a' || ch > 'z') && (ch < 'A' || ch > 'Z') {
+			_ = r.UnreadByte()
+			break
+		}
+		word = append(word, ch|0x20)
+	}
+
+	var g Gimel
+	switch string(word) {
+	case "":
+		return nil, nil
+	case "inf", "infinity":
+		g = Inf(neg, p)
+	case "nan", "snan":
+		var b big.Int
+		if _, err := scanDecimalDigitsLimit(r, &b, nil); err != nil {
+			return nil, err
+		}
+		f := qnan
+		if word[0] == 's' {
+			f = snan
+		}
+		g = special(f, neg, &b, p, RoundHalfEven)
+	default:
+		return nil, &tokenError{expected: errInvalidSpecialValue.expected, back: len(word)}
+	}
+	return &g, nil
+}
+
 func scanDecimalDigit(r io.ByteScanner) (n int, err error) {
 	var ch byte
 	if ch, err = r.ReadByte(); err != nil {
@@ -149,53 +225,112 @@ func scanDecimalDigitAppender(r io.ByteScanner, b *big.Int, push bool) error {
 	return nil
 }
 
-func scanNumeric(r byteRuneScanner, p *big.Int) (*Gimel, error) {
+func scanNumeric(r ByteRuneScanner, p *big.Int) (*Gimel, error) {
+	return scanDecimal(r, p, false)
+}
+
+func scanScientific(r ByteRuneScanner, p *big.Int) (*Gimel, error) {
+	return scanDecimal(r, p, true)
+}
+
+// scanDecimal scans a decimal number with an optional fractional part
+// if exponent is true then an exponent starting with 'e' or 'E' may follow the digits
+// the number is rounded to p digits using RoundHalfEven, or keeps all the digits if p is nil
+func scanDecimal(r ByteRuneScanner, p *big.Int, exponent bool) (*Gimel, error) {
 	neg, err := scanSign(r)
 	if err != nil {
+		if err == io.EOF {
+			err = errInvalidDecimalDigit
+		}
 		return nil, err
 	}
+	if g, err := scanSpecial(r, neg, specialPrec(p)); g != nil || err != nil {
+		return g, err
+	}
 
+	// all the digits are kept so the number is rounded correctly
 	var b big.Int
-	_, err = scanDecimalDigitsLimit(r, &b, p)
+	n, err := scanDecimalDigitsLimit(r, &b, nil)
 	if err != nil {
 		return nil, err
 	}
+	var f *big.Int
+	if ch, err := r.ReadByte(); err == nil {
+		if ch == '.' {
+			if f, err = scanDecimalDigitsLimit(r, &b, nil); err != nil {
+				return nil, err
+			}
+			n.Add(n, f)
+		} else {
+			_ = r.UnreadByte()
+		}
+	}
+	if n.Sign() == 0 {
+		return nil, errInvalidDecimalDigit
+	}
 
-	n, err := scanDecimalDigitsLimit(r, nil, nil)
-	if err != nil {
-		return nil, err
+	// the exponent of the last digit
+	var e big.Int
+	if exponent {
+		if e2, err := scanExponent(r); err != nil {
+			return nil, err
+		} else if e2 != nil {
+			e.Set(e2)
+		}
+	}
+	if f != nil {
+		e.Sub(&e, f)
+	}
+
+	// without a precision all the digits are kept
+	if p == nil {
+		p = big.NewInt(int64(len(strings.TrimLeft(b.String(), "0"))))
+		if p.Sign() == 0 {
+			p.SetInt64(1)
+		}
 	}
 
-	g := G(neg, &b, n, p)
+	// the leading digit has the exponent e+p-1 if b is lined up to p digits
+	e.Add(&e, p)
+	e.Sub(&e, oneValue)
+	g, _ := g2(neg, &b, &e, p, RoundHalfEven).normShift()
 	return &g, nil
 }
 
-func scanScientific(r byteRuneScanner, p *big.Int) (*Gimel, error) {
-	neg, err := scanSign(r)
-	if err != nil {
-		return nil, err
-	}
-
-	var b big.Int
-	_, err = scanDecimalDigitsLimit(r, &b, p)
-	if err != nil {
-		return nil, err
+// specialPrec is an internal function to get the precision of an infinity or NaN
+// a single digit is used if the precision is unknown
+func specialPrec(p *big.Int) *big.Int {
+	if p == nil {
+		return oneValue
 	}
+	return p
+}
 
+// scanExponent scans an exponent in the form e[+-]ddd
+// nil is returned if there is no exponent
+func scanExponent(r ByteRuneScanner) (*big.Int, error) {
 	ch, err := r.ReadByte()
 	if err != nil {
-		return nil, err
+		return nil, nil
 	}
-	if ch != 'e' {
+	if ch != 'e' && ch != 'E' {
+		_ = r.UnreadByte()
+		return nil, nil
+	}
+	neg, err := scanSign(r)
+	if err != nil {
 		return nil, errInvalidScientificNotation
 	}
-
-	var b2 big.Int
-	_, err = scanDecimalDigitsLimit(r, &b, nil)
+	var e big.Int
+	n, err := scanDecimalDigitsLimit(r, &e, nil)
 	if err != nil {
 		return nil, err
 	}
-
-	g := G(neg, &b, &b2, p)
-	return &g, nil
+	if n.Sign() == 0 {
+		return nil, errInvalidScientificNotation
+	}
+	if neg {
+		e.Neg(&e)
+	}
+	return &e, nil
 }
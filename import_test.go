@@ -1 +1,138 @@
 package gimel
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+)
+
+func TestFromString_Special(t *testing.T) {
+	for _, f := range []Format{Auto, Numeric, Scientific} {
+		for s, e := range map[string]string{
+			"NaN":       "NaN",
+			"nan":       "NaN",
+			"-NaN":      "-NaN",
+			"NaN123":    "NaN123",
+			"sNaN":      "sNaN",
+			"Infinity":  "Infinity",
+			"+Infinity": "Infinity",
+			"-Inf":      "-Infinity",
+			"inf":       "Infinity",
+		} {
+			a, ok := FromString(s, f, prec)
+			assert.True(t, ok, s)
+			assert.Equal(t, e, a.String(), s)
+		}
+		_, ok := FromString("Infinite", f, prec)
+		assert.False(t, ok)
+		_, ok = FromString("Inf5", f, prec)
+		assert.False(t, ok)
+	}
+
+	a, ok := FromString("-0", Numeric, prec)
+	assert.True(t, ok)
+	assert.True(t, a.IsZero())
+	assert.True(t, a.IsNeg())
+}
+
+func TestFromString(t *testing.T) {
+	for s, e := range map[string]string{
+		"0":           "0",
+		"-0":          "-0",
+		"123":         "1.23e2",
+		"3.14":        "3.14e0",
+		".5":          "5e-1",
+		"5.":          "5e0",
+		"-0.00123":    "-1.23e-3",
+		"1.2e-7":      "1.2e-7",
+		"6.02E+23":    "6.02e23",
+		"12.5e3":      "1.25e4",
+		"1234567":     "1.2346e6",
+		"1234565":     "1.2346e6",
+		"1234555":     "1.2346e6",
+		"0.000012345": "1.2345e-5",
+	} {
+		a, ok := FromString(s, Auto, prec)
+		assert.True(t, ok, s)
+		assert.Equal(t, e, a.String(), s)
+	}
+
+	for _, s := range []string{"", ".", "-", "1e", "1e+", "e5", "1.2.3", "1..2", "1e5.5"} {
+		_, ok := FromString(s, Auto, prec)
+		assert.False(t, ok, s)
+	}
+	_, ok := FromString("1e5", Numeric, prec)
+	assert.False(t, ok)
+	a, ok := FromString("1.5", Scientific, prec)
+	assert.True(t, ok)
+	assert.Equal(t, "1.5e0", a.String())
+}
+
+func TestFromString_RoundTrip(t *testing.T) {
+	for _, g := range []Gimel{
+		gen(false, 123, 6),
+		gen(true, 3456, 15),
+		gen(false, 12345, 3),
+		gen(true, 12345, -3),
+		gen(false, 1, -20),
+		gen(true, 0, 0),
+	} {
+		a, ok := FromString(g.TextE(), Auto, prec)
+		assert.True(t, ok, g.TextE())
+		assert.True(t, g.Eq(a), g.TextE())
+		a, ok = FromString(g.Text(0), Auto, prec)
+		assert.True(t, ok, g.Text(0))
+		assert.True(t, g.Eq(a), g.Text(0))
+	}
+}
+
+func TestParse(t *testing.T) {
+	a, err := Parse("3.14159")
+	assert.NoError(t, err)
+	assert.Equal(t, "3.14159e0", a.String())
+	assert.Equal(t, big.NewInt(6), a.prec)
+	a, err = Parse("0.00120")
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(3), a.prec)
+	a, err = Parse("-0")
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(1), a.prec)
+	a, err = Parse("-Inf")
+	assert.NoError(t, err)
+	assert.Equal(t, "-Infinity", a.String())
+
+	a, err = Parse("3.14159", WithPrecision(prec))
+	assert.NoError(t, err)
+	assert.Equal(t, "3.1416e0", a.String())
+	_, err = Parse("1e5", WithFormat(Numeric))
+	assert.Equal(t, &ParseError{"1e5", 1, 'e', "end of input"}, err)
+	_, err = Parse("1", WithFormat(Format(100)))
+	assert.Error(t, err)
+	_, err = Parse("1", WithPrecision(big.NewInt(0)))
+	assert.Equal(t, &OpError{"parse", ErrPrecision}, err)
+}
+
+func TestParse_Error(t *testing.T) {
+	for s, e := range map[string]*ParseError{
+		"":          {"", 0, -1, "digit"},
+		"-":         {"-", 1, -1, "digit"},
+		".":         {".", 1, -1, "digit"},
+		"abc123":    {"abc123", 0, 'a', "digit, Infinity or NaN"},
+		"12abc":     {"12abc", 2, 'a', "end of input"},
+		"1e":        {"1e", 2, -1, "exponent digit"},
+		"1e+x":      {"1e+x", 3, 'x', "exponent digit"},
+		"1.2.3":     {"1.2.3", 3, '.', "end of input"},
+		"-Infinite": {"-Infinite", 1, 'I', "digit, Infinity or NaN"},
+		"Inf5":      {"Inf5", 3, '5', "end of input"},
+		"12€":       {"12€", 2, '€', "end of input"},
+	} {
+		_, err := Parse(s)
+		assert.Equal(t, e, err, s)
+		assert.True(t, errors.Is(err, ErrSyntax), s)
+	}
+	_, err := Parse("12abc")
+	assert.EqualError(t, err, `gimel: parsing "12abc": unexpected 'a' at offset 2, expected end of input`)
+	_, err = Parse("1e")
+	assert.EqualError(t, err, `gimel: parsing "1e": unexpected end of input at offset 2, expected exponent digit`)
+}
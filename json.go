@@ -0,0 +1,63 @@
+package gimel
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"strconv"
+)
+
+// QuotedJSON is a Gimel number which is marshalled to JSON as a quoted string
+// instead of a JSON number, both are accepted when unmarshalling
+type QuotedJSON struct{ Gimel }
+
+// MarshalJSON implements json.Marshaler
+//
+// The number is written as an exact JSON number in the TextE form. Infinities
+// and NaNs are always written as strings because JSON numbers can't represent
+// them, use QuotedJSON to write every number as a string.
+func (g Gimel) MarshalJSON() ([]byte, error) { return g.marshalJSON(false) }
+
+// MarshalJSON implements json.Marshaler with the number written as a quoted string
+func (q QuotedJSON) MarshalJSON() ([]byte, error) { return q.Gimel.marshalJSON(true) }
+
+// marshalJSON is an internal function to write the number as a JSON number or a quoted string
+func (g Gimel) marshalJSON(quoted bool) ([]byte, error) {
+	if g.digits == nil {
+		return nil, &OpError{"marshal", ErrPrecision}
+	}
+	s := g.TextE()
+	if quoted || g.form != finite {
+		return []byte(strconv.Quote(s)), nil
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+//
+// Both JSON numbers and strings are accepted, null leaves the number unchanged.
+// The precision of g is kept if it has one, otherwise all the digits are kept.
+func (g *Gimel) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+	s := string(b)
+	if len(b) > 0 && b[0] == '"' {
+		var err error
+		if s, err = strconv.Unquote(s); err != nil {
+			return err
+		}
+	}
+	a, err := FromJSONNumber(json.Number(s), g.prec)
+	if err != nil {
+		return err
+	}
+	*g = a
+	return nil
+}
+
+// FromJSONNumber returns the Gimel number from a json.Number with a precision
+// if prec is nil then all the digits are kept
+func FromJSONNumber(n json.Number, prec *big.Int) (Gimel, error) {
+	return Parse(string(n), WithPrecision(prec))
+}
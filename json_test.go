@@ -0,0 +1,68 @@
+package gimel
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestGimel_MarshalJSON(t *testing.T) {
+	b, err := json.Marshal([]Gimel{gen(false, 123, 6), gen(true, 15, -3), gen(true, 0, 0), Inf(true, prec), NaN(prec)})
+	assert.NoError(t, err)
+	assert.Equal(t, `[1.23e6,-1.5e-3,-0,"-Infinity","NaN"]`, string(b))
+
+	b, err = json.Marshal(map[string]QuotedJSON{"a": {gen(false, 123, 6)}, "b": {NaN(prec)}})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":"1.23e6","b":"NaN"}`, string(b))
+
+	// quoted and unquoted numbers are both accepted
+	var q struct{ A, B QuotedJSON }
+	assert.NoError(t, json.Unmarshal([]byte(`{"A":"1.5","B":2.5}`), &q))
+	assert.Equal(t, "1.5e0", q.A.String())
+	assert.Equal(t, "2.5e0", q.B.String())
+
+	_, err = json.Marshal(Gimel{})
+	assert.Error(t, err)
+}
+
+func TestGimel_UnmarshalJSON(t *testing.T) {
+	var a struct {
+		A, B, C Gimel
+		D       *Gimel
+	}
+	a.B = gen(false, 0, 0)
+	err := json.Unmarshal([]byte(`{"A":3.14159,"B":3.14159,"C":"-Infinity","D":null}`), &a)
+	assert.NoError(t, err)
+	assert.Equal(t, "3.14159e0", a.A.String())
+	assert.Equal(t, "3.1416e0", a.B.String())
+	assert.Equal(t, "-Infinity", a.C.String())
+	assert.Nil(t, a.D)
+
+	err = json.Unmarshal([]byte(`{"A":"1x"}`), &a)
+	assert.True(t, errors.Is(err, ErrSyntax))
+	err = json.Unmarshal([]byte(`{"A":true}`), &a)
+	assert.Error(t, err)
+}
+
+func TestGimel_JSONRoundTrip(t *testing.T) {
+	b, err := json.Marshal(Pi)
+	assert.NoError(t, err)
+	assert.Equal(t, "3."+_PiDigits[1:]+"e0", string(b))
+
+	var a Gimel
+	assert.NoError(t, json.Unmarshal(b, &a))
+	assert.Equal(t, 0, Pi.Cmp(a))
+	assert.Equal(t, big.NewInt(100), a.prec)
+
+	// json.Number values from a decoder using UseNumber never pass through float64
+	var v map[string]any
+	d := json.NewDecoder(strings.NewReader(`{"pi":` + string(b) + `}`))
+	d.UseNumber()
+	assert.NoError(t, d.Decode(&v))
+	a, err = FromJSONNumber(v["pi"].(json.Number), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, Pi.Cmp(a))
+}
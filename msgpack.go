@@ -0,0 +1,85 @@
+package gimel
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/bits"
+)
+
+// MsgpackExtType is the MessagePack extension type used by AppendMsgpack and ReadMsgpack
+const MsgpackExtType int8 = 0x47
+
+// MessagePack extension formats
+const (
+	msgpackFixExt1 = 0xd4
+	msgpackExt8    = 0xc7
+	msgpackExt16   = 0xc8
+	msgpackExt32   = 0xc9
+)
+
+var errInvalidMsgpack = errors.New("gimel: invalid MessagePack extension")
+
+// AppendMsgpack appends g to b as a MessagePack extension of type MsgpackExtType
+//
+// The extension data is the MarshalBinary encoding with the sign, exponent,
+// precision, rounding mode and digit bytes. The smallest fixext or ext format
+// is used for the length of the data. Like MarshalBinary the exponent must fit
+// in an int64, larger exponents return an error and b is returned unchanged.
+func AppendMsgpack(b []byte, g Gimel) ([]byte, error) {
+	data, err := g.appendBinary(nil)
+	if err != nil {
+		return b, err
+	}
+	switch l := len(data); {
+	case l == 1, l == 2, l == 4, l == 8, l == 16:
+		// fixext 1, 2, 4, 8 and 16 are consecutive
+		b = append(b, msgpackFixExt1+byte(bits.TrailingZeros(uint(l))))
+	case l <= math.MaxUint8:
+		b = append(b, msgpackExt8, byte(l))
+	case l <= math.MaxUint16:
+		b = binary.BigEndian.AppendUint16(append(b, msgpackExt16), uint16(l))
+	case uint64(l) <= math.MaxUint32:
+		b = binary.BigEndian.AppendUint32(append(b, msgpackExt32), uint32(l))
+	default:
+		return b, errBinaryRange
+	}
+	b = append(b, byte(MsgpackExtType))
+	return append(b, data...), nil
+}
+
+// ReadMsgpack reads a MessagePack extension of type MsgpackExtType from the start of b
+// the remaining bytes after the extension are also returned
+func ReadMsgpack(b []byte) (Gimel, []byte, error) {
+	if len(b) == 0 {
+		return Gimel{}, b, errInvalidMsgpack
+	}
+	var size uint64
+	var n int
+	switch c := b[0]; {
+	case c >= msgpackFixExt1 && c <= msgpackFixExt1+4:
+		size, n = 1<<(c-msgpackFixExt1), 1
+	case c == msgpackExt8 && len(b) >= 2:
+		size, n = uint64(b[1]), 2
+	case c == msgpackExt16 && len(b) >= 3:
+		size, n = uint64(binary.BigEndian.Uint16(b[1:])), 3
+	case c == msgpackExt32 && len(b) >= 5:
+		size, n = uint64(binary.BigEndian.Uint32(b[1:])), 5
+	default:
+		return Gimel{}, b, errInvalidMsgpack
+	}
+	// the length is checked before converting to int as it can overflow on 32-bit platforms
+	if len(b) <= n || int8(b[n]) != MsgpackExtType || size > uint64(len(b)-n-1) {
+		return Gimel{}, b, errInvalidMsgpack
+	}
+	l := int(size)
+	data := b[n+1 : n+1+l]
+	g, i, err := readBinary(data)
+	if err != nil {
+		return Gimel{}, b, err
+	}
+	if i != l {
+		return Gimel{}, b, errInvalidMsgpack
+	}
+	return g, b[n+1+l:], nil
+}
@@ -0,0 +1,69 @@
+package gimel
+
+import (
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestAppendMsgpack(t *testing.T) {
+	for _, g := range []Gimel{
+		gen(false, 12345, 2),
+		gen(true, 5, -3),
+		gen(false, 0, 0),
+		Inf(true, prec),
+		NaN(prec),
+		G(false, strToBigInt(strings.Repeat("9", 700)), big.NewInt(10), big.NewInt(700)),
+	} {
+		b, err := AppendMsgpack([]byte{0x01}, g)
+		assert.NoError(t, err)
+		assert.Equal(t, byte(0x01), b[0])
+
+		a, rest, err := ReadMsgpack(append(b[1:], 0x02))
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x02}, rest)
+		assert.True(t, identical(g, a), g.String())
+	}
+
+	// 9.9999e2 has 8 bytes of data so it uses fixext 8
+	b, err := AppendMsgpack(nil, gen(false, 99999, 2))
+	assert.NoError(t, err)
+	assert.Equal(t, "d74701", hex.EncodeToString(b[:3]))
+	assert.Len(t, b, 10)
+
+	b, err = AppendMsgpack([]byte{0x01}, Gimel{})
+	assert.Error(t, err)
+	assert.Equal(t, []byte{0x01}, b)
+
+	// the exponent is limited to an int64 by the binary encoding
+	b, err = AppendMsgpack([]byte{0x01}, G(false, big.NewInt(1), new(big.Int).Lsh(big.NewInt(1), 70), prec))
+	assert.Equal(t, errBinaryRange, err)
+	assert.Equal(t, []byte{0x01}, b)
+}
+
+func TestReadMsgpack(t *testing.T) {
+	b, err := AppendMsgpack(nil, gen(true, 5, -3))
+	assert.NoError(t, err)
+	assert.Equal(t, byte(msgpackExt8), b[0])
+
+	// the same data in a larger ext format
+	ext32 := append([]byte{msgpackExt32, 0, 0, 0, b[1]}, b[2:]...)
+	g, rest, err := ReadMsgpack(ext32)
+	assert.NoError(t, err)
+	assert.Empty(t, rest)
+	assert.True(t, g.Eq(gen(true, 5, -3)))
+
+	for _, i := range [][]byte{
+		nil, {0xc0}, {msgpackExt8}, {msgpackExt8, 1}, b[:len(b)-1],
+		append([]byte{b[0], b[1], 0x01}, b[3:]...),
+		append([]byte{b[0], b[1] + 1}, append(b[2:], 0x00)...),
+		// a length with the top bit set must not wrap around to a negative int
+		append([]byte{msgpackExt32, 0xff, 0xff, 0xff, 0xff}, b[2:]...),
+	} {
+		_, rest, err := ReadMsgpack(i)
+		assert.Error(t, err, hex.EncodeToString(i))
+		assert.Equal(t, i, rest)
+	}
+}
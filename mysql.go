@@ -0,0 +1,167 @@
+package gimel
+
+import (
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+const (
+	// mysqlMaxPrecision and mysqlMaxScale are the largest M and D allowed for DECIMAL(M,D)
+	mysqlMaxPrecision = 65
+	mysqlMaxScale     = 30
+	// mysqlGroupDigits is the number of decimal digits stored in each 4 byte group
+	mysqlGroupDigits = 9
+)
+
+var (
+	errMySQLDecimalType  = errors.New("gimel: invalid MySQL DECIMAL(M,D) type")
+	errMySQLDecimalRange = errors.New("gimel: number is out of range for MySQL DECIMAL")
+	errInvalidMySQL      = errors.New("gimel: invalid MySQL DECIMAL")
+
+	// mysqlDigitBytes is the number of bytes used to store a group with fewer than 9 digits
+	mysqlDigitBytes = [mysqlGroupDigits]int{0, 1, 1, 2, 2, 3, 3, 4, 4}
+)
+
+// mysqlDecimalSize is an internal function to check a DECIMAL(M,D) type and return the size of its binary format
+func mysqlDecimalSize(m, d int) (int, error) {
+	if m < 1 || m > mysqlMaxPrecision || d < 0 || d > mysqlMaxScale || d > m {
+		return 0, errMySQLDecimalType
+	}
+	return mysqlPartSize(m-d) + mysqlPartSize(d), nil
+}
+
+// mysqlPartSize is an internal function to get the number of bytes used to store n digits
+func mysqlPartSize(n int) int {
+	return n/mysqlGroupDigits*4 + mysqlDigitBytes[n%mysqlGroupDigits]
+}
+
+// EncodeMySQLDecimal returns the MySQL binary storage format of g as a DECIMAL(M,D)
+//
+// The integer digits are stored before the D fractional digits, 9 digits are
+// packed into each 4 byte big-endian group and the remaining integer digits are
+// stored first and the remaining fractional digits last. Negative numbers have
+// all of their bits inverted and the highest bit is flipped so the bytes sort in
+// numeric order. The number is rounded to D fractional digits using its rounding
+// mode, an error is returned if the integer part has more than M-D digits.
+func EncodeMySQLDecimal(g Gimel, m, d int) ([]byte, error) {
+	size, err := mysqlDecimalSize(m, d)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPrec("mysqldecimal", g); err != nil {
+		return nil, err
+	}
+	if !g.IsFinite() {
+		return nil, errMySQLDecimalRange
+	}
+
+	// n is the number scaled by 10^D
+	n, ok := g.scaledDigits(int64(d), int64(m))
+	if !ok {
+		return nil, errMySQLDecimalRange
+	}
+	s := n.String()
+	if len(s) > m {
+		return nil, errMySQLDecimalRange
+	}
+	s = strings.Repeat("0", m-len(s)) + s
+
+	b := make([]byte, 0, size)
+	intg, frac := s[:m-d], s[m-d:]
+	x := len(intg) % mysqlGroupDigits
+	b = appendMySQLGroup(b, intg[:x])
+	for i := x; i < len(intg); i += mysqlGroupDigits {
+		b = appendMySQLGroup(b, intg[i:i+mysqlGroupDigits])
+	}
+	for ; len(frac) >= mysqlGroupDigits; frac = frac[mysqlGroupDigits:] {
+		b = appendMySQLGroup(b, frac[:mysqlGroupDigits])
+	}
+	b = appendMySQLGroup(b, frac)
+
+	if g.neg && n.Sign() != 0 {
+		for i := range b {
+			b[i] ^= 0xff
+		}
+	}
+	b[0] ^= 0x80
+	return b, nil
+}
+
+// appendMySQLGroup is an internal function to append a group of up to 9 digits as a big-endian number
+func appendMySQLGroup(b []byte, s string) []byte {
+	if s == "" {
+		return b
+	}
+	v, _ := strconv.ParseUint(s, 10, 32)
+	n := 4
+	if len(s) < mysqlGroupDigits {
+		n = mysqlDigitBytes[len(s)]
+	}
+	for i := n - 1; i >= 0; i-- {
+		b = append(b, byte(v>>(8*i)))
+	}
+	return b
+}
+
+// DecodeMySQLDecimal returns the Gimel number from the MySQL binary storage format of a DECIMAL(M,D)
+// the number is exact and has a precision of M digits
+func DecodeMySQLDecimal(b []byte, m, d int) (Gimel, error) {
+	size, err := mysqlDecimalSize(m, d)
+	if err != nil {
+		return Gimel{}, err
+	}
+	if len(b) != size {
+		return Gimel{}, errInvalidMySQL
+	}
+
+	// undo the sign flip
+	c := make([]byte, len(b))
+	copy(c, b)
+	c[0] ^= 0x80
+	neg := c[0]&0x80 != 0
+	if neg {
+		for i := range c {
+			c[i] ^= 0xff
+		}
+	}
+
+	var s strings.Builder
+	read := func(n int) error {
+		if n == 0 {
+			return nil
+		}
+		l := mysqlPartSize(n)
+		var v uint64
+		for _, i := range c[:l] {
+			v = v<<8 | uint64(i)
+		}
+		c = c[l:]
+		t := strconv.FormatUint(v, 10)
+		if len(t) > n {
+			return errInvalidMySQL
+		}
+		s.WriteString(strings.Repeat("0", n-len(t)))
+		s.WriteString(t)
+		return nil
+	}
+	intg, frac := m-d, d
+	x := intg % mysqlGroupDigits
+	groups := []int{x}
+	for i := x; i < intg; i += mysqlGroupDigits {
+		groups = append(groups, mysqlGroupDigits)
+	}
+	for ; frac >= mysqlGroupDigits; frac -= mysqlGroupDigits {
+		groups = append(groups, mysqlGroupDigits)
+	}
+	groups = append(groups, frac)
+	for _, n := range groups {
+		if err := read(n); err != nil {
+			return Gimel{}, err
+		}
+	}
+
+	n, _ := new(big.Int).SetString(s.String(), 10)
+	return fromCoef(neg, n, big.NewInt(int64(-d)), big.NewInt(int64(m))), nil
+}
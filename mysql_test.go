@@ -0,0 +1,86 @@
+package gimel
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+)
+
+func TestEncodeMySQLDecimal(t *testing.T) {
+	for _, i := range []struct {
+		s    string
+		m, d int
+		b    []byte
+	}{
+		// examples from the MySQL source
+		{"1234567890.1234", 14, 4, []byte{0x81, 0x0d, 0xfb, 0x38, 0xd2, 0x04, 0xd2}},
+		{"-1234567890.1234", 14, 4, []byte{0x7e, 0xf2, 0x04, 0xc7, 0x2d, 0xfb, 0x2d}},
+		{"0", 5, 2, []byte{0x80, 0x00, 0x00}},
+		{"12.5", 5, 2, []byte{0x80, 0x0c, 0x32}},
+		{"-12.5", 5, 2, []byte{0x7f, 0xf3, 0xcd}},
+		{"0.123456789012", 12, 12, []byte{0x87, 0x5b, 0xcd, 0x15, 0x00, 0x0c}},
+		{"1", 1, 0, []byte{0x81}},
+	} {
+		g, err := Parse(i.s)
+		assert.NoError(t, err)
+		b, err := EncodeMySQLDecimal(g, i.m, i.d)
+		assert.NoError(t, err)
+		assert.Equal(t, i.b, b, i.s)
+
+		a, err := DecodeMySQLDecimal(b, i.m, i.d)
+		assert.NoError(t, err)
+		assert.True(t, g.Eq(a), i.s)
+		assert.Equal(t, big.NewInt(int64(i.m)), a.prec)
+	}
+
+	// the fractional digits are rounded with the rounding mode
+	b, err := EncodeMySQLDecimal(gen(false, 12345, 1), 5, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x80, 0x0c, 0x22}, b)
+	b, err = EncodeMySQLDecimal(gen(false, 12345, 1).Rounding(RoundUp), 5, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x80, 0x0c, 0x23}, b)
+	b, err = EncodeMySQLDecimal(gen(true, 1, -5), 5, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x80, 0x00, 0x00}, b)
+
+	// huge and tiny exponents are handled without scaling by the exponent
+	b, err = EncodeMySQLDecimal(gen(false, 1, -30000000).Rounding(RoundUp), 5, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x80, 0x00, 0x01}, b)
+
+	for _, i := range []struct {
+		g    Gimel
+		m, d int
+	}{
+		{gen(false, 1, 3), 5, 2},
+		{gen(false, 1, 30000000), 10, 2},
+		{Inf(false, prec), 5, 2},
+		{NaN(prec), 5, 2},
+		{gen(false, 1, 0), 0, 0},
+		{gen(false, 1, 0), 66, 2},
+		{gen(false, 1, 0), 40, 31},
+		{gen(false, 1, 0), 2, 3},
+		{Gimel{}, 5, 2},
+	} {
+		_, err := EncodeMySQLDecimal(i.g, i.m, i.d)
+		assert.Error(t, err)
+	}
+}
+
+func TestDecodeMySQLDecimal(t *testing.T) {
+	a, err := DecodeMySQLDecimal([]byte{0x81, 0x0d, 0xfb, 0x38, 0xd2, 0x04, 0xd2}, 14, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, "1234567890.1234", a.Text(0))
+	a, err = DecodeMySQLDecimal([]byte{0x80, 0x00, 0x00}, 5, 2)
+	assert.NoError(t, err)
+	assert.True(t, a.IsZero())
+
+	_, err = DecodeMySQLDecimal([]byte{0x80, 0x00}, 5, 2)
+	assert.Error(t, err)
+	_, err = DecodeMySQLDecimal([]byte{0x80, 0x00, 0x00}, 5, 6)
+	assert.Error(t, err)
+	// 127 doesn't fit in 2 digits
+	_, err = DecodeMySQLDecimal([]byte{0xff, 0x00}, 4, 2)
+	assert.Error(t, err)
+}
@@ -0,0 +1,147 @@
+package gimel
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+)
+
+// PostgreSQL NUMERIC sign values
+const (
+	pgNumericPos  = 0x0000
+	pgNumericNeg  = 0x4000
+	pgNumericNaN  = 0xc000
+	pgNumericPInf = 0xd000
+	pgNumericNInf = 0xf000
+
+	// pgNumericMaxScale is the largest display scale allowed by PostgreSQL
+	pgNumericMaxScale = 0x3fff
+)
+
+var (
+	errInvalidPgNumeric = errors.New("gimel: invalid PostgreSQL NUMERIC")
+	errPgNumericRange   = errors.New("gimel: number is out of range for PostgreSQL NUMERIC")
+
+	pgNumericBase = big.NewInt(10000)
+)
+
+// EncodePgNumeric returns the PostgreSQL NUMERIC binary wire format of g
+//
+// The format is a header of four 16-bit values: the number of digits, the
+// weight of the first digit, the sign and the display scale, followed by
+// base-10000 digits. All the digits of g are kept so the display scale is the
+// number of fractional digits. NUMERIC has no negative zero or NaN payloads.
+func EncodePgNumeric(g Gimel) ([]byte, error) {
+	if err := checkPrec("pgnumeric", g); err != nil {
+		return nil, err
+	}
+	switch {
+	case g.IsNaN():
+		return pgNumericHeader(0, 0, pgNumericNaN, 0), nil
+	case g.IsInf() && g.neg:
+		return pgNumericHeader(0, 0, pgNumericNInf, 0), nil
+	case g.IsInf():
+		return pgNumericHeader(0, 0, pgNumericPInf, 0), nil
+	}
+
+	u := g.unitExp()
+	if !u.IsInt64() || u.Int64() < -pgNumericMaxScale {
+		return nil, errPgNumericRange
+	}
+	var scale int64
+	if u.Sign() == -1 {
+		scale = -u.Int64()
+	}
+	if g.digits.Sign() == 0 {
+		return pgNumericHeader(0, 0, pgNumericPos, uint16(scale)), nil
+	}
+
+	// line up the exponent of the last digit to a multiple of 4
+	d := new(big.Int).Set(g.digits)
+	shift := ((u.Int64() % 4) + 4) % 4
+	d.Mul(d, new(big.Int).Exp(tenValue, big.NewInt(shift), nil))
+	k := (u.Int64() - shift) / 4
+
+	var groups []uint16
+	var r big.Int
+	for d.Sign() != 0 {
+		d.QuoRem(d, pgNumericBase, &r)
+		groups = append(groups, uint16(r.Int64()))
+	}
+	// trailing zero groups are not stored
+	for groups[0] == 0 {
+		groups = groups[1:]
+		k++
+	}
+	weight := k + int64(len(groups)) - 1
+	if weight < -0x8000 || weight > 0x7fff || len(groups) > 0x7fff {
+		return nil, errPgNumericRange
+	}
+
+	sign := uint16(pgNumericPos)
+	if g.neg {
+		sign = pgNumericNeg
+	}
+	b := pgNumericHeader(len(groups), int16(weight), sign, uint16(scale))
+	for i := len(groups) - 1; i >= 0; i-- {
+		b = binary.BigEndian.AppendUint16(b, groups[i])
+	}
+	return b, nil
+}
+
+// pgNumericHeader is an internal function to write the header of the PostgreSQL NUMERIC format
+func pgNumericHeader(ndigits int, weight int16, sign, scale uint16) []byte {
+	b := make([]byte, 8, 8+2*ndigits)
+	binary.BigEndian.PutUint16(b, uint16(ndigits))
+	binary.BigEndian.PutUint16(b[2:], uint16(weight))
+	binary.BigEndian.PutUint16(b[4:], sign)
+	binary.BigEndian.PutUint16(b[6:], scale)
+	return b
+}
+
+// DecodePgNumeric returns the Gimel number from the PostgreSQL NUMERIC binary wire format
+// if prec is nil then the precision is the number of digits up to the display scale
+func DecodePgNumeric(data []byte, prec *big.Int) (Gimel, error) {
+	if len(data) < 8 {
+		return Gimel{}, errInvalidPgNumeric
+	}
+	ndigits := int(binary.BigEndian.Uint16(data))
+	weight := int64(int16(binary.BigEndian.Uint16(data[2:])))
+	sign := binary.BigEndian.Uint16(data[4:])
+	scale := int64(binary.BigEndian.Uint16(data[6:]))
+	if ndigits > 0x7fff || len(data) != 8+2*ndigits {
+		return Gimel{}, errInvalidPgNumeric
+	}
+
+	switch sign {
+	case pgNumericNaN:
+		return NaN(specialPrec(prec)), nil
+	case pgNumericPInf, pgNumericNInf:
+		return Inf(sign == pgNumericNInf, specialPrec(prec)), nil
+	case pgNumericPos, pgNumericNeg:
+	default:
+		return Gimel{}, errInvalidPgNumeric
+	}
+	if scale > pgNumericMaxScale {
+		return Gimel{}, errInvalidPgNumeric
+	}
+
+	c := new(big.Int)
+	for i := 0; i < ndigits; i++ {
+		v := binary.BigEndian.Uint16(data[8+2*i:])
+		if v >= 10000 {
+			return Gimel{}, errInvalidPgNumeric
+		}
+		c.Mul(c, pgNumericBase)
+		c.Add(c, big.NewInt(int64(v)))
+	}
+
+	// the digits are lined up to the display scale, any digits after the scale are zero
+	e := 4 * (weight - int64(ndigits) + 1)
+	if e > -scale {
+		c.Mul(c, new(big.Int).Exp(tenValue, big.NewInt(e+scale), nil))
+	} else {
+		c.Quo(c, new(big.Int).Exp(tenValue, big.NewInt(-scale-e), nil))
+	}
+	return fromCoef(sign == pgNumericNeg, c, big.NewInt(-scale), prec), nil
+}
@@ -0,0 +1,80 @@
+package gimel
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+)
+
+func TestEncodePgNumeric(t *testing.T) {
+	for _, i := range []struct {
+		s string
+		b []byte
+	}{
+		{"12345.678", []byte{0, 3, 0, 1, 0, 0, 0, 3, 0, 1, 0x09, 0x29, 0x1a, 0x7c}},
+		{"-0.0001", []byte{0, 1, 0xff, 0xff, 0x40, 0, 0, 4, 0, 1}},
+		{"100000000", []byte{0, 1, 0, 2, 0, 0, 0, 0, 0, 1}},
+		{"0", []byte{0, 0, 0, 0, 0, 0, 0, 0}},
+		{"NaN", []byte{0, 0, 0, 0, 0xc0, 0, 0, 0}},
+		{"Infinity", []byte{0, 0, 0, 0, 0xd0, 0, 0, 0}},
+		{"-Infinity", []byte{0, 0, 0, 0, 0xf0, 0, 0, 0}},
+	} {
+		g, err := Parse(i.s)
+		assert.NoError(t, err)
+		b, err := EncodePgNumeric(g)
+		assert.NoError(t, err)
+		assert.Equal(t, i.b, b, i.s)
+
+		a, err := DecodePgNumeric(b, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, i.s, a.Text(0), i.s)
+	}
+
+	// the display scale keeps the trailing zeros
+	b, err := EncodePgNumeric(gen(false, 15, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0, 2, 0, 0, 0, 0, 0, 4, 0, 1, 0x13, 0x88}, b)
+	b, err = EncodePgNumeric(gen(true, 0, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0, 4}, b)
+
+	_, err = EncodePgNumeric(gen(false, 1, -20000))
+	assert.Error(t, err)
+	_, err = EncodePgNumeric(gen(false, 1, 200000))
+	assert.Error(t, err)
+	_, err = EncodePgNumeric(Gimel{})
+	assert.Error(t, err)
+}
+
+func TestDecodePgNumeric(t *testing.T) {
+	a, err := DecodePgNumeric([]byte{0, 3, 0, 1, 0, 0, 0, 3, 0, 1, 0x09, 0x29, 0x1a, 0x7c}, prec)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2346e4", a.String())
+
+	// a display scale larger than the digits adds precision
+	a, err = DecodePgNumeric([]byte{0, 1, 0, 0, 0, 0, 0, 3, 0, 1}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "1e0", a.String())
+	assert.Equal(t, big.NewInt(4), a.prec)
+
+	for _, b := range [][]byte{
+		nil,
+		{0, 1, 0, 0, 0, 0, 0, 0},
+		{0, 1, 0, 0, 0, 0, 0, 0, 0x27, 0x10},
+		{0, 0, 0, 0, 0x80, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0x40, 0},
+	} {
+		_, err = DecodePgNumeric(b, nil)
+		assert.Error(t, err, b)
+	}
+}
+
+func TestPgNumeric_RoundTrip(t *testing.T) {
+	for _, g := range []Gimel{Pi, Euler.Neg(), gen(false, 12345, -9), gen(false, 12345, 30), gen(false, 1, 3)} {
+		b, err := EncodePgNumeric(g)
+		assert.NoError(t, err)
+		a, err := DecodePgNumeric(b, g.prec)
+		assert.NoError(t, err)
+		assert.True(t, identical(g, a), g.String())
+	}
+}
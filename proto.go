@@ -0,0 +1,192 @@
+package gimel
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+)
+
+// protobuf wire types
+const (
+	protoVarint = 0
+	protoI64    = 1
+	protoLen    = 2
+	protoI32    = 5
+)
+
+// exponents outside this range are written in scientific form by EncodeProtoDecimal
+const (
+	protoMinPlainExp = -6
+	protoMaxPlainExp = 20
+)
+
+var (
+	errInvalidProto = errors.New("gimel: invalid protobuf message")
+	errProtoRange   = errors.New("gimel: number is out of range for the protobuf message")
+)
+
+// EncodeProtoDecimal returns the protobuf wire format of g as a google.type.Decimal message
+//
+//	message Decimal {
+//	  string value = 1;
+//	}
+//
+// The value is the decimal text of g without an exponent, unless the exponent
+// is below -6 or above 20 where the scientific form like 1.5e-7 is used so a
+// huge exponent doesn't write out every zero. The message has no form for
+// infinity or NaN so an error is returned for them.
+func EncodeProtoDecimal(g Gimel) ([]byte, error) {
+	if err := checkPrec("protodecimal", g); err != nil {
+		return nil, err
+	}
+	if !g.IsFinite() {
+		return nil, errProtoRange
+	}
+	if g.digits.Sign() != 0 && (g.exp.Cmp(big.NewInt(protoMinPlainExp)) == -1 || g.exp.Cmp(big.NewInt(protoMaxPlainExp)) == 1) {
+		return appendProtoBytes(nil, 1, []byte(g.TextE())), nil
+	}
+	return appendProtoBytes(nil, 1, []byte(g.Text(0))), nil
+}
+
+// DecodeProtoDecimal returns the Gimel number from the protobuf wire format of a google.type.Decimal message
+// an empty value is zero, if prec is nil then all the digits are kept
+func DecodeProtoDecimal(b []byte, prec *big.Int) (Gimel, error) {
+	var s string
+	err := readProto(b, func(field int, typ byte, v uint64, data []byte) error {
+		if field != 1 {
+			return nil
+		}
+		if typ != protoLen {
+			return errInvalidProto
+		}
+		s = string(data)
+		return nil
+	})
+	if err != nil {
+		return Gimel{}, err
+	}
+	// the last value wins and an empty value is zero
+	if s == "" {
+		s = "0"
+	}
+	g, err := Parse(s, WithPrecision(prec))
+	if err != nil {
+		return Gimel{}, err
+	}
+	if !g.IsFinite() {
+		return Gimel{}, errInvalidProto
+	}
+	return g, nil
+}
+
+// EncodeProtoGimel returns the protobuf wire format of g as an unscaled integer and an exponent
+//
+//	message Gimel {
+//	  bytes unscaled = 1; // big-endian two's complement
+//	  sint64 exponent = 2;
+//	}
+//
+// The value is unscaled*10^exponent and every digit of g is kept so the
+// precision is the number of digits in unscaled. Fields with the default value
+// are left out like proto3 does. The message has no form for infinity, NaN or
+// negative zero.
+func EncodeProtoGimel(g Gimel) ([]byte, error) {
+	if err := checkPrec("protogimel", g); err != nil {
+		return nil, err
+	}
+	u := g.unitExp()
+	if !g.IsFinite() || !u.IsInt64() {
+		return nil, errProtoRange
+	}
+	var b []byte
+	if g.digits.Sign() != 0 {
+		d, err := g.ToUnscaled(int(-u.Int64()), 0)
+		if err != nil {
+			return nil, err
+		}
+		b = appendProtoBytes(b, 1, d)
+	}
+	if e := u.Int64(); e != 0 {
+		b = binary.AppendUvarint(b, 2<<3|protoVarint)
+		b = binary.AppendVarint(b, e)
+	}
+	return b, nil
+}
+
+// DecodeProtoGimel returns the Gimel number from the protobuf wire format written by EncodeProtoGimel
+// if prec is nil then the precision is the number of digits in unscaled
+func DecodeProtoGimel(b []byte, prec *big.Int) (Gimel, error) {
+	var unscaled []byte
+	var e int64
+	err := readProto(b, func(field int, typ byte, v uint64, data []byte) error {
+		switch {
+		case field == 1 && typ == protoLen:
+			unscaled = data
+		case field == 2 && typ == protoVarint:
+			// sint64 uses zigzag encoding
+			e = int64(v>>1) ^ -int64(v&1)
+		case field == 1, field == 2:
+			return errInvalidProto
+		}
+		return nil
+	})
+	if err != nil {
+		return Gimel{}, err
+	}
+	g := FromUnscaled(unscaled, 0)
+	return fromCoef(g.neg, g.digits, big.NewInt(e), prec), nil
+}
+
+// appendProtoBytes is an internal function to append a length-delimited field
+func appendProtoBytes(b []byte, field int, data []byte) []byte {
+	b = binary.AppendUvarint(b, uint64(field)<<3|protoLen)
+	b = binary.AppendUvarint(b, uint64(len(data)))
+	return append(b, data...)
+}
+
+// readProto is an internal function to call fn for each field of a protobuf message
+// v is set for varint and fixed-size fields and data is set for length-delimited fields
+func readProto(b []byte, fn func(field int, typ byte, v uint64, data []byte) error) error {
+	for len(b) > 0 {
+		tag, n := binary.Uvarint(b)
+		if n <= 0 || tag>>3 == 0 || tag>>3 > 1<<29-1 {
+			return errInvalidProto
+		}
+		b = b[n:]
+
+		var v uint64
+		var data []byte
+		typ := byte(tag & 7)
+		switch typ {
+		case protoVarint:
+			v, n = binary.Uvarint(b)
+			if n <= 0 {
+				return errInvalidProto
+			}
+		case protoI64:
+			if len(b) < 8 {
+				return errInvalidProto
+			}
+			v, n = binary.LittleEndian.Uint64(b), 8
+		case protoI32:
+			if len(b) < 4 {
+				return errInvalidProto
+			}
+			v, n = uint64(binary.LittleEndian.Uint32(b)), 4
+		case protoLen:
+			l, i := binary.Uvarint(b)
+			if i <= 0 || l > uint64(len(b)-i) {
+				return errInvalidProto
+			}
+			data, n = b[i:i+int(l)], i+int(l)
+		default:
+			// groups are not supported
+			return errInvalidProto
+		}
+		b = b[n:]
+		if err := fn(int(tag>>3), typ, v, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
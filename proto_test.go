@@ -0,0 +1,92 @@
+package gimel
+
+import (
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+)
+
+func TestProtoDecimal(t *testing.T) {
+	for _, i := range []struct {
+		g Gimel
+		b string
+	}{
+		{gen(false, 12345, 2), "0a06" + hex.EncodeToString([]byte("123.45"))},
+		{gen(true, 5, -3), "0a06" + hex.EncodeToString([]byte("-0.005"))},
+		{gen(false, 0, 0), "0a0130"},
+		{gen(false, 15, 1000000000), "0a0e" + hex.EncodeToString([]byte("1.5e1000000000"))},
+		{gen(true, 15, -7), "0a07" + hex.EncodeToString([]byte("-1.5e-7"))},
+	} {
+		b, err := EncodeProtoDecimal(i.g)
+		assert.NoError(t, err)
+		assert.Equal(t, i.b, hex.EncodeToString(b), i.g.String())
+
+		a, err := DecodeProtoDecimal(b, prec)
+		assert.NoError(t, err)
+		assert.True(t, i.g.Eq(a), i.g.String())
+	}
+
+	_, err := EncodeProtoDecimal(Inf(false, prec))
+	assert.Error(t, err)
+
+	// unknown fields are skipped, the last value wins and an empty value is zero
+	for _, i := range []struct {
+		b string
+		s string
+	}{
+		{"", "0"},
+		{"0a00", "0"},
+		{"1001" + "1d00000000" + "0a03312e35", "1.5"},
+		{"0a0132" + "0a05322e356531", "2.5e1"},
+		{"0a03312e35" + "0a00", "0"},
+	} {
+		b, _ := hex.DecodeString(i.b)
+		g, err := DecodeProtoDecimal(b, nil)
+		assert.NoError(t, err, i.b)
+		a, _ := Parse(i.s)
+		assert.True(t, a.Eq(g), i.b)
+	}
+
+	for _, i := range []string{"0a", "0801", "0a0378797a", "0a03496e66", "0b", "00"} {
+		b, _ := hex.DecodeString(i)
+		_, err := DecodeProtoDecimal(b, nil)
+		assert.Error(t, err, i)
+	}
+}
+
+func TestProtoGimel(t *testing.T) {
+	for _, i := range []struct {
+		g Gimel
+		b string
+	}{
+		{gen(false, 12345, 2), "0a0230391003"},
+		{gen(true, 5, -3), "0a03ff3cb0100d"},
+		{gen(false, 0, 0), "1007"},
+		{G(false, big.NewInt(7), big.NewInt(0), big.NewInt(1)), "0a0107"},
+	} {
+		b, err := EncodeProtoGimel(i.g)
+		assert.NoError(t, err)
+		assert.Equal(t, i.b, hex.EncodeToString(b), i.g.String())
+
+		a, err := DecodeProtoGimel(b, nil)
+		assert.NoError(t, err)
+		assert.True(t, i.g.Eq(a), i.g.String())
+		if !i.g.IsZero() {
+			assert.Equal(t, i.g.prec, a.prec)
+		}
+	}
+
+	_, err := EncodeProtoGimel(NaN(prec))
+	assert.Error(t, err)
+
+	g, err := DecodeProtoGimel([]byte{0x0a, 0x02, 0x30, 0x39, 0x10, 0x03}, big.NewInt(2))
+	assert.NoError(t, err)
+	assert.True(t, g.Eq(G(false, big.NewInt(12), big.NewInt(2), big.NewInt(2))))
+
+	for _, i := range []string{"0801", "1201", "0a05", "1080"} {
+		b, _ := hex.DecodeString(i)
+		_, err := DecodeProtoGimel(b, nil)
+		assert.Error(t, err, i)
+	}
+}
@@ -0,0 +1,109 @@
+package gimel
+
+import (
+	"math/big"
+	"strconv"
+)
+
+// RoundingMode determines how digits are discarded when a Gimel number is
+// reduced to its precision
+type RoundingMode byte
+
+const (
+	RoundHalfEven RoundingMode = iota // round to nearest, ties to the even digit, this is the default
+	RoundDown                         // truncate towards zero
+	RoundHalfUp                       // round to nearest, ties away from zero
+	RoundHalfDown                     // round to nearest, ties towards zero
+	RoundUp                           // round away from zero
+	RoundCeiling                      // round towards +Inf
+	RoundFloor                        // round towards -Inf
+	Round05Up                         // round away from zero if the last digit would be 0 or 5, otherwise towards zero
+)
+
+var roundingModeNames = [...]string{
+	RoundHalfEven: "RoundHalfEven",
+	RoundDown:     "RoundDown",
+	RoundHalfUp:   "RoundHalfUp",
+	RoundHalfDown: "RoundHalfDown",
+	RoundUp:       "RoundUp",
+	RoundCeiling:  "RoundCeiling",
+	RoundFloor:    "RoundFloor",
+	Round05Up:     "Round05Up",
+}
+
+// String returns the name of the rounding mode
+func (m RoundingMode) String() string {
+	if int(m) < len(roundingModeNames) {
+		return roundingModeNames[m]
+	}
+	return "RoundingMode(" + strconv.Itoa(int(m)) + ")"
+}
+
+// parseRoundingMode is an internal function to find the rounding mode with a name
+func parseRoundingMode(s string) (RoundingMode, bool) {
+	for i, n := range roundingModeNames {
+		if n == s {
+			return RoundingMode(i), true
+		}
+	}
+	return 0, false
+}
+
+// roundDigits is an internal function to divide the absolute digits d by 10^n in place
+// the discarded digits are used to round the result using the rounding mode
+// the returned boolean is true if any non-zero digits were discarded
+func (m RoundingMode) roundDigits(neg bool, d, n *big.Int) bool {
+	var p, r big.Int
+	p.Exp(tenValue, n, nil)
+	d.QuoRem(d, &p, &r)
+	if r.Sign() == 0 {
+		return false
+	}
+	if m.roundUp(neg, d, &r, &p) {
+		d.Add(d, oneValue)
+	}
+	return true
+}
+
+// roundUp is an internal function to decide if the truncated digits q should be incremented
+// r is the non-zero remainder which was discarded from a division by p
+func (m RoundingMode) roundUp(neg bool, q, r, p *big.Int) bool {
+	switch m {
+	case RoundUp:
+		return true
+	case RoundCeiling:
+		return !neg
+	case RoundFloor:
+		return neg
+	case Round05Up:
+		var l big.Int
+		l.Mod(q, tenValue)
+		return l.Sign() == 0 || l.Int64() == 5
+	case RoundHalfEven, RoundHalfUp, RoundHalfDown:
+		var h big.Int
+		h.Lsh(r, 1)
+		switch h.Cmp(p) {
+		case 1:
+			return true
+		case 0:
+			switch m {
+			case RoundHalfUp:
+				return true
+			case RoundHalfEven:
+				return q.Bit(0) == 1
+			}
+		}
+	}
+	return false
+}
+
+// Mode returns the rounding mode used by the Gimel number
+func (g Gimel) Mode() RoundingMode { return g.mode }
+
+// Rounding returns a new Gimel struct with a different rounding mode
+// the rounding mode is used by any operation which needs to discard digits
+func (g Gimel) Rounding(mode RoundingMode) Gimel {
+	a := g.Clone()
+	a.mode = mode
+	return a
+}
@@ -0,0 +1,62 @@
+package gimel
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+)
+
+func genMode(neg bool, d, e int64, mode RoundingMode) Gimel {
+	return gen(neg, d, e).Rounding(mode)
+}
+
+func TestRoundingMode_String(t *testing.T) {
+	assert.Equal(t, "RoundHalfEven", RoundHalfEven.String())
+	assert.Equal(t, "Round05Up", Round05Up.String())
+	assert.Equal(t, "RoundingMode(20)", RoundingMode(20).String())
+}
+
+func TestGimel_Rounding(t *testing.T) {
+	two := big.NewInt(2)
+	for _, i := range []struct {
+		mode     RoundingMode
+		pos, neg int64
+	}{
+		{RoundHalfEven, 12, 12},
+		{RoundHalfUp, 13, 13},
+		{RoundHalfDown, 12, 12},
+		{RoundUp, 13, 13},
+		{RoundDown, 12, 12},
+		{RoundCeiling, 13, 12},
+		{RoundFloor, 12, 13},
+		{Round05Up, 12, 12},
+	} {
+		// 1.25 and -1.25 rounded to 2 digits
+		assert.Equal(t, i.pos, genMode(false, 125, 0, i.mode).Precision(two).digits.Int64(), i.mode.String())
+		assert.Equal(t, i.neg, genMode(true, 125, 0, i.mode).Precision(two).digits.Int64(), i.mode.String())
+	}
+
+	// ties only matter for half modes
+	assert.Equal(t, int64(13), genMode(false, 1251, 0, RoundHalfDown).Precision(two).digits.Int64())
+	assert.Equal(t, int64(14), genMode(false, 135, 0, RoundHalfEven).Precision(two).digits.Int64())
+
+	// 05up only rounds away from zero when the last digit is 0 or 5
+	assert.Equal(t, int64(16), genMode(false, 151, 0, Round05Up).Precision(two).digits.Int64())
+	assert.Equal(t, int64(11), genMode(false, 101, 0, Round05Up).Precision(two).digits.Int64())
+	assert.Equal(t, int64(12), genMode(false, 121, 0, Round05Up).Precision(two).digits.Int64())
+
+	// rounding can carry into the exponent
+	a := genMode(false, 999, 0, RoundUp).Precision(two)
+	assert.Equal(t, "1e1", a.TextE())
+	assert.Equal(t, int64(10), a.digits.Int64())
+}
+
+func TestGimel_RoundingOperations(t *testing.T) {
+	assert.Equal(t, "6.6667e-1", gen(false, 2, 0).Div(gen(false, 3, 0)).TextE())
+	assert.Equal(t, "6.6666e-1", genMode(false, 2, 0, RoundDown).Div(gen(false, 3, 0)).TextE())
+	assert.Equal(t, "1.0001e10", genMode(false, 1, 10, RoundUp).Add(gen(false, 1, 1)).TextE())
+	assert.Equal(t, "1e10", genMode(false, 1, 10, RoundHalfUp).Add(gen(false, 1, 1)).TextE())
+	assert.Equal(t, "9.9999e9", genMode(false, 1, 10, RoundDown).Sub(gen(false, 1, 1)).TextE())
+	assert.Equal(t, "1.2346e8", genMode(false, 11111, 4, RoundCeiling).Mul(gen(false, 11111, 4)).TextE())
+	assert.Equal(t, "1.2345e8", genMode(false, 11111, 4, RoundFloor).Mul(gen(false, 11111, 4)).TextE())
+}
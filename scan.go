@@ -0,0 +1,57 @@
+package gimel
+
+import (
+	"fmt"
+	"io"
+	"unicode/utf8"
+)
+
+// scanState is an internal type to read bytes from a fmt.ScanState
+// non-ASCII runes are read as an invalid byte so the scanners stop at them
+type scanState struct{ fmt.ScanState }
+
+func (s scanState) ReadByte() (byte, error) {
+	ch, _, err := s.ReadRune()
+	if err != nil {
+		return 0, err
+	}
+	if ch >= utf8.RuneSelf {
+		return utf8.RuneSelf, nil
+	}
+	return byte(ch), nil
+}
+
+func (s scanState) UnreadByte() error { return s.UnreadRune() }
+
+// Scan implements fmt.Scanner
+//
+// The verbs f and F read a number without an exponent, the verbs v, e, E, g
+// and G also accept an exponent. The precision of g is kept if it has one,
+// otherwise all the digits which were read are kept.
+func (g *Gimel) Scan(s fmt.ScanState, verb rune) error {
+	var scan ScannerCallback
+	switch verb {
+	case 'f', 'F':
+		scan = scanNumeric
+	case 'v', 'e', 'E', 'g', 'G':
+		scan = scanScientific
+	default:
+		return fmt.Errorf("gimel: scan: bad verb '%%%c'", verb)
+	}
+
+	s.SkipSpace()
+	if _, _, err := s.ReadRune(); err != nil {
+		return err
+	}
+	_ = s.UnreadRune()
+
+	a, err := scan(scanState{s}, g.prec)
+	if err != nil {
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		}
+		return fmt.Errorf("gimel: scan: %s: %w", err, ErrSyntax)
+	}
+	*g = *a
+	return nil
+}
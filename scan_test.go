@@ -0,0 +1,56 @@
+package gimel
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestGimel_Scan(t *testing.T) {
+	var a, b, c Gimel
+	n, err := fmt.Sscan("3.14159 -2e-3\n Infinity", &a, &b, &c)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, "3.14159e0", a.String())
+	assert.Equal(t, big.NewInt(6), a.prec)
+	assert.Equal(t, "-2e-3", b.String())
+	assert.Equal(t, "Infinity", c.String())
+
+	// the precision of the Gimel is kept
+	a = gen(false, 0, 0)
+	_, err = fmt.Sscan("3.14159", &a)
+	assert.NoError(t, err)
+	assert.Equal(t, "3.1416e0", a.String())
+
+	// the exponent is not read by %f
+	var e string
+	n, err = fmt.Sscanf("1.5e3", "%f%s", &a, &e)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, "1.5e0", a.String())
+	assert.Equal(t, "e3", e)
+	_, err = fmt.Sscanf("x", "%e", &a)
+	assert.True(t, errors.Is(err, ErrSyntax))
+	_, err = fmt.Sscanf("1", "%d", &a)
+	assert.EqualError(t, err, "gimel: scan: bad verb '%d'")
+}
+
+func TestGimel_ScanPrec(t *testing.T) {
+	// numbers are rounded to the precision they already have
+	p := G(false, big.NewInt(0), big.NewInt(0), big.NewInt(3))
+	a, b, c := p, p, p
+
+	r := strings.NewReader("12345 0.1\n6.02e23")
+	_, err := fmt.Fscan(r, &a, &b, &c)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.23e4", a.String())
+	assert.Equal(t, "1e-1", b.String())
+	assert.Equal(t, "6.02e23", c.String())
+
+	_, err = fmt.Fscan(r, &a)
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+}
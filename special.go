@@ -0,0 +1,142 @@
+package gimel
+
+import "math/big"
+
+// form is the kind of value held by a Gimel number
+type form byte
+
+const (
+	finite form = iota // a finite number including signed zero
+	inf                // a signed infinity
+	qnan               // a quiet NaN which propagates through operations
+	snan               // a signalling NaN which raises InvalidOperation when used
+)
+
+// Inf returns a signed infinity
+func Inf(neg bool, prec *big.Int) Gimel {
+	return special(inf, neg, big.NewInt(0), prec, RoundHalfEven)
+}
+
+// NaN returns a quiet NaN
+func NaN(prec *big.Int) Gimel {
+	return special(qnan, false, big.NewInt(0), prec, RoundHalfEven)
+}
+
+// SNaN returns a signalling NaN
+func SNaN(prec *big.Int) Gimel {
+	return special(snan, false, big.NewInt(0), prec, RoundHalfEven)
+}
+
+// special is an internal function to return a non-finite Gimel number
+// the digits of a NaN hold the diagnostic payload
+func special(f form, neg bool, payload, prec *big.Int, mode RoundingMode) Gimel {
+	g := g2(neg, payload, big.NewInt(0), prec, mode)
+	g.form = f
+	return g
+}
+
+// IsNaN returns true if the number is a quiet or signalling NaN
+func (g Gimel) IsNaN() bool { return g.form == qnan || g.form == snan }
+
+// IsSNaN returns true if the number is a signalling NaN
+func (g Gimel) IsSNaN() bool { return g.form == snan }
+
+// IsInf returns true if the number is +Inf or -Inf
+func (g Gimel) IsInf() bool { return g.form == inf }
+
+// IsFinite returns true if the number is not an infinity or NaN
+func (g Gimel) IsFinite() bool { return g.form == finite }
+
+// IsZero returns true if the number is +0 or -0
+func (g Gimel) IsZero() bool { return g.form == finite && g.digits.Sign() == 0 }
+
+// Sign returns:
+//
+// -1 if g <  0
+//
+//	0 if g is ±0 or NaN
+//
+// +1 if g >  0
+func (g Gimel) Sign() int {
+	switch {
+	case g.IsNaN(), g.IsZero():
+		return 0
+	case g.neg:
+		return -1
+	}
+	return 1
+}
+
+// nanResult is an internal function to return the quiet NaN result of an invalid operation
+func nanResult(prec *big.Int, mode RoundingMode) (Gimel, Condition) {
+	return special(qnan, false, big.NewInt(0), prec, mode), InvalidOperation
+}
+
+// infResult is an internal function to return a signed infinity result
+func infResult(neg bool, prec *big.Int, mode RoundingMode) Gimel {
+	return special(inf, neg, big.NewInt(0), prec, mode)
+}
+
+// nanOperand is an internal function to propagate NaN operands to the result
+// signalling NaNs take priority, raise InvalidOperation and are converted to quiet NaNs
+// the returned boolean is false if none of the operands were NaN
+func nanOperand(prec *big.Int, mode RoundingMode, a ...Gimel) (Gimel, Condition, bool) {
+	for _, f := range []form{snan, qnan} {
+		for _, i := range a {
+			if i.form == f {
+				var cond Condition
+				if f == snan {
+					cond = InvalidOperation
+				}
+				return special(qnan, i.neg, new(big.Int).Set(i.digits), prec, mode), cond, true
+			}
+		}
+	}
+	return Gimel{}, 0, false
+}
+
+// zeroSumSign is an internal function to find the sign of an exact zero sum of g and o
+// the sign is kept if both operands have the same sign, otherwise the sum is +0, or -0 when rounding towards -Inf
+func (g Gimel) zeroSumSign(o Gimel, mode RoundingMode) bool {
+	if g.neg == o.neg {
+		return g.neg
+	}
+	return mode == RoundFloor
+}
+
+// overflowToInf is an internal function to check if an overflowing result becomes infinity
+// otherwise the result is the largest finite number
+func (m RoundingMode) overflowToInf(neg bool) bool {
+	switch m {
+	case RoundDown, Round05Up:
+		return false
+	case RoundCeiling:
+		return !neg
+	case RoundFloor:
+		return neg
+	}
+	return true
+}
+
+// specialText is an internal function to get the text representation of non-finite numbers
+func (g Gimel) specialText() (string, bool) {
+	var s string
+	switch g.form {
+	case finite:
+		return "", false
+	case inf:
+		s = "Infinity"
+	case qnan, snan:
+		s = "NaN"
+		if g.digits.Sign() != 0 {
+			s += g.digits.String()
+		}
+		if g.form == snan {
+			s = "s" + s
+		}
+	}
+	if g.neg {
+		s = "-" + s
+	}
+	return s, true
+}
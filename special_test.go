@@ -0,0 +1,102 @@
+package gimel
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGimel_Special(t *testing.T) {
+	assert.True(t, NaN(prec).IsNaN())
+	assert.False(t, NaN(prec).IsSNaN())
+	assert.True(t, SNaN(prec).IsNaN())
+	assert.True(t, SNaN(prec).IsSNaN())
+	assert.True(t, Inf(true, prec).IsInf())
+	assert.True(t, Inf(true, prec).IsNeg())
+	assert.False(t, Inf(false, prec).IsFinite())
+	assert.True(t, gen(true, 0, 0).IsZero())
+	assert.True(t, gen(true, 0, 0).IsFinite())
+	assert.False(t, Inf(false, prec).IsInt())
+	assert.Nil(t, NaN(prec).BigInt())
+
+	assert.Equal(t, 0, NaN(prec).Sign())
+	assert.Equal(t, 0, gen(true, 0, 0).Sign())
+	assert.Equal(t, -1, Inf(true, prec).Sign())
+	assert.Equal(t, 1, gen(false, 1, 0).Sign())
+}
+
+func TestGimel_SpecialText(t *testing.T) {
+	assert.Equal(t, "Infinity", Inf(false, prec).TextE())
+	assert.Equal(t, "-Infinity", Inf(true, prec).Text(','))
+	assert.Equal(t, "NaN", NaN(prec).String())
+	assert.Equal(t, "-NaN", NaN(prec).Neg().String())
+	assert.Equal(t, "sNaN", SNaN(prec).String())
+	assert.Equal(t, "-0", gen(true, 0, 0).Text(0))
+}
+
+func TestGimel_SpecialAdd(t *testing.T) {
+	assert.Equal(t, "Infinity", Inf(false, prec).Add(gen(true, 1, 50)).String())
+	assert.Equal(t, "-Infinity", gen(false, 1, 50).Add(Inf(true, prec)).String())
+	assert.Equal(t, "Infinity", Inf(false, prec).Add(Inf(false, prec)).String())
+	assert.True(t, Inf(false, prec).Sub(Inf(false, prec)).IsNaN())
+	assert.True(t, NaN(prec).Add(gen(false, 1, 0)).IsNaN())
+	assert.True(t, gen(false, 1, 0).Add(SNaN(prec)).IsNaN())
+	assert.False(t, gen(false, 1, 0).Add(SNaN(prec)).IsSNaN())
+
+	// signed zero
+	assert.Equal(t, "-0", gen(true, 0, 0).Add(gen(true, 0, 0)).String())
+	assert.Equal(t, "0", gen(true, 0, 0).Add(gen(false, 0, 0)).String())
+	assert.Equal(t, "0", gen(false, 5, 0).Sub(gen(false, 5, 0)).String())
+	assert.Equal(t, "-0", genMode(false, 5, 0, RoundFloor).Sub(gen(false, 5, 0)).String())
+	assert.Equal(t, "-5e0", gen(true, 0, 0).Add(gen(true, 5, 0)).String())
+}
+
+func TestGimel_SpecialMul(t *testing.T) {
+	assert.Equal(t, "-Infinity", Inf(false, prec).Mul(gen(true, 2, 0)).String())
+	assert.True(t, Inf(false, prec).Mul(gen(true, 0, 0)).IsNaN())
+	assert.True(t, NaN(prec).Mul(gen(false, 2, 0)).IsNaN())
+	assert.Equal(t, "-0", gen(false, 0, 0).Mul(gen(true, 2, 0)).String())
+}
+
+func TestGimel_SpecialDiv(t *testing.T) {
+	assert.Equal(t, "Infinity", gen(false, 1, 0).Div(gen(false, 0, 0)).String())
+	assert.Equal(t, "-Infinity", gen(false, 1, 0).Div(gen(true, 0, 0)).String())
+	assert.True(t, gen(false, 0, 0).Div(gen(false, 0, 0)).IsNaN())
+	assert.True(t, Inf(false, prec).Div(Inf(true, prec)).IsNaN())
+	assert.Equal(t, "-Infinity", Inf(false, prec).Div(gen(true, 2, 0)).String())
+	assert.Equal(t, "-0", gen(false, 2, 0).Div(Inf(true, prec)).String())
+}
+
+func TestGimel_SpecialCmp(t *testing.T) {
+	assert.Equal(t, 0, gen(true, 0, 0).Cmp(gen(false, 0, 0)))
+	assert.Equal(t, 1, Inf(false, prec).Cmp(gen(false, 1, 100)))
+	assert.Equal(t, -1, Inf(true, prec).Cmp(gen(true, 1, 100)))
+	assert.Equal(t, 0, Inf(true, prec).Cmp(Inf(true, prec)))
+	assert.Equal(t, -1, NaN(prec).Cmp(Inf(true, prec)))
+	assert.Equal(t, 1, gen(false, 1, 0).Cmp(NaN(prec)))
+	assert.Equal(t, 0, NaN(prec).Cmp(NaN(prec)))
+
+	// zero is smaller than any positive number
+	assert.Equal(t, -1, gen(false, 0, 15).Cmp(gen(false, 1, 2)))
+	assert.Equal(t, 1, gen(false, 0, 15).Cmp(gen(true, 1, 2)))
+
+	// comparisons with NaN are always false
+	assert.False(t, NaN(prec).Eq(NaN(prec)))
+	assert.True(t, NaN(prec).Neq(NaN(prec)))
+	assert.False(t, NaN(prec).Lt(gen(false, 1, 0)))
+	assert.False(t, NaN(prec).Gte(gen(false, 1, 0)))
+	assert.True(t, gen(true, 0, 0).Eq(gen(false, 0, 0)))
+}
+
+func TestGimel_SpecialTranscendental(t *testing.T) {
+	assert.True(t, gen(true, 2, 0).Ln().IsNaN())
+	assert.Equal(t, "-Infinity", gen(false, 0, 0).Ln().String())
+	assert.Equal(t, "-Infinity", gen(true, 0, 0).Ln().String())
+	assert.Equal(t, "Infinity", Inf(false, prec).Ln().String())
+	assert.True(t, NaN(prec).Ln().IsNaN())
+	assert.True(t, gen(true, 2, 0).Log10().IsNaN())
+	assert.Equal(t, "Infinity", gen(false, 2, 0).Log(gen(false, 1, 0)).String())
+	assert.True(t, gen(false, 1, 0).Log(gen(false, 1, 0)).IsNaN())
+	assert.Equal(t, "Infinity", Inf(false, prec).Exp().String())
+	assert.Equal(t, "0", Inf(true, prec).Exp().String())
+	assert.True(t, NaN(prec).Exp().IsNaN())
+}
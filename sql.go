@@ -0,0 +1,117 @@
+package gimel
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// FloatPolicy decides how float64 values from a database are converted
+type FloatPolicy byte
+
+const (
+	FloatReject   FloatPolicy = iota // float64 values are rejected with an error, this is the default
+	FloatShortest                    // the shortest decimal which converts back to the same float64
+	FloatExact                       // the exact decimal value of the float64
+)
+
+// Value implements driver.Valuer
+//
+// Finite numbers are written as a decimal string which is accepted by
+// NUMERIC and DECIMAL columns, infinities and NaNs use their String form.
+func (g Gimel) Value() (driver.Value, error) {
+	if err := checkPrec("value", g); err != nil {
+		return nil, err
+	}
+	return g.Text(0), nil
+}
+
+// NullGimel represents a Gimel number which may be null, it implements
+// sql.Scanner so it can be used as a scan destination
+type NullGimel struct {
+	Gimel       Gimel
+	Valid       bool        // Valid is true if Gimel is not NULL
+	FloatPolicy FloatPolicy // FloatPolicy converts float64 values, the zero value rejects them
+}
+
+// Scan implements sql.Scanner
+//
+// The source can be a string, []byte, int64 or float64 which is converted
+// using n.FloatPolicy. The precision of n.Gimel is kept if it has one,
+// otherwise all the digits are kept.
+func (n *NullGimel) Scan(src any) error {
+	if src == nil {
+		n.Gimel, n.Valid = Gimel{}, false
+		return nil
+	}
+	a, err := fromSQL(src, n.Gimel.prec, n.FloatPolicy)
+	if err != nil {
+		return err
+	}
+	n.Gimel, n.Valid = a, true
+	return nil
+}
+
+// Value implements driver.Valuer
+func (n NullGimel) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Gimel.Value()
+}
+
+// SQLScanner returns a sql.Scanner which scans a non-null value into g
+// float64 values are converted using the policy
+//
+// Gimel can't implement sql.Scanner itself because its Scan method is used by fmt.Scanner.
+func SQLScanner(g *Gimel, policy FloatPolicy) sql.Scanner { return sqlScanner{g, policy} }
+
+// sqlScanner is an internal type to scan a database value into a Gimel number
+type sqlScanner struct {
+	g      *Gimel
+	policy FloatPolicy
+}
+
+func (s sqlScanner) Scan(src any) error {
+	if src == nil {
+		return fmt.Errorf("gimel: converting NULL to Gimel is unsupported")
+	}
+	a, err := fromSQL(src, s.g.prec, s.policy)
+	if err != nil {
+		return err
+	}
+	*s.g = a
+	return nil
+}
+
+// fromSQL is an internal function to convert a database value to a Gimel number
+func fromSQL(src any, prec *big.Int, policy FloatPolicy) (Gimel, error) {
+	var s string
+	switch v := src.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	case int64:
+		s = strconv.FormatInt(v, 10)
+	case float64:
+		switch policy {
+		case FloatShortest:
+			s = strconv.FormatFloat(v, 'g', -1, 64)
+		case FloatExact:
+			// 767 significant digits is enough for the exact value of any float64
+			s = strconv.FormatFloat(v, 'e', 767, 64)
+			if m, e, ok := strings.Cut(s, "e"); ok {
+				s = strings.TrimRight(strings.TrimRight(m, "0"), ".") + "e" + e
+			}
+		default:
+			return Gimel{}, fmt.Errorf("gimel: converting float64 to Gimel is rejected by the FloatPolicy")
+		}
+	default:
+		return Gimel{}, fmt.Errorf("gimel: converting %T to Gimel is unsupported", src)
+	}
+	return Parse(s, WithPrecision(prec))
+}
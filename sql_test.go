@@ -0,0 +1,118 @@
+package gimel
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"math"
+	"testing"
+)
+
+// fakeDriver is an in-process driver which returns the arguments of a query as a single row
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{}, nil }
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(string) (driver.Stmt, error) { return fakeStmt{}, nil }
+func (fakeConn) Close() error                        { return nil }
+func (fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+type fakeStmt struct{}
+
+func (fakeStmt) Close() error  { return nil }
+func (fakeStmt) NumInput() int { return -1 }
+func (fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+func (fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &fakeRows{args, false}, nil
+}
+
+type fakeRows struct {
+	values []driver.Value
+	done   bool
+}
+
+func (r *fakeRows) Columns() []string { return make([]string, len(r.values)) }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	copy(dest, r.values)
+	return nil
+}
+
+func init() {
+	sql.Register("gimel-fake", fakeDriver{})
+}
+
+func TestGimel_SQL(t *testing.T) {
+	db, err := sql.Open("gimel-fake", "")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer db.Close()
+
+	// the arguments are converted with driver.Valuer and returned by the query
+	var a, b Gimel
+	var c, d NullGimel
+	b = gen(false, 0, 0)
+	err = db.QueryRow("", gen(false, 12345, -3), "3.14159", NullGimel{Gimel: gen(true, 15, 1), Valid: true}, NullGimel{}).
+		Scan(SQLScanner(&a, FloatReject), SQLScanner(&b, FloatReject), &c, &d)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2345e-3", a.String())
+	assert.Equal(t, "3.1416e0", b.String())
+	assert.True(t, c.Valid)
+	assert.Equal(t, "-1.5e1", c.Gimel.String())
+	assert.False(t, d.Valid)
+
+	err = db.QueryRow("", nil).Scan(SQLScanner(&a, FloatReject))
+	assert.Error(t, err)
+	err = db.QueryRow("", Inf(true, prec)).Scan(SQLScanner(&a, FloatReject))
+	assert.NoError(t, err)
+	assert.Equal(t, "-Infinity", a.String())
+}
+
+func TestNullGimel_Scan(t *testing.T) {
+	var n NullGimel
+	assert.NoError(t, n.Scan([]byte("-12.5")))
+	assert.Equal(t, "-1.25e1", n.Gimel.String())
+	n = NullGimel{}
+	assert.NoError(t, n.Scan(int64(-9007199254740993)))
+	assert.Equal(t, "-9.007199254740993e15", n.Gimel.String())
+	assert.Error(t, n.Scan(true))
+	assert.Error(t, n.Scan("1.5.5"))
+	assert.NoError(t, n.Scan(nil))
+	assert.False(t, n.Valid)
+
+	assert.Error(t, n.Scan(0.1))
+	n.FloatPolicy = FloatShortest
+	assert.NoError(t, n.Scan(0.1))
+	assert.Equal(t, "1e-1", n.Gimel.String())
+	assert.NoError(t, n.Scan(math.Inf(-1)))
+	assert.Equal(t, "-Infinity", n.Gimel.String())
+	n = NullGimel{FloatPolicy: FloatExact}
+	assert.NoError(t, n.Scan(0.1))
+	assert.Equal(t, "1.000000000000000055511151231257827021181583404541015625e-1", n.Gimel.String())
+	n.Gimel = gen(false, 0, 0)
+	assert.NoError(t, n.Scan(0.1))
+	assert.Equal(t, "1e-1", n.Gimel.String())
+
+	v, err := n.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "0.1", v)
+	v, err = NullGimel{}.Value()
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+	_, err = Gimel{}.Value()
+	assert.Error(t, err)
+	v, err = gen(false, 1, 20).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "100000000000000000000", v)
+}
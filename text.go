@@ -0,0 +1,78 @@
+package gimel
+
+import (
+	"math/big"
+	"strings"
+)
+
+// maxPrec is the largest precision accepted when decoding, it stops a short
+// input from building a huge power of ten
+const maxPrec = 1 << 20
+
+// MarshalText implements encoding.TextMarshaler
+//
+// The text is in the lossless form <number>:<precision>[:<rounding mode>],
+// for example 1.23e6:5 or -0e3:5:RoundDown. The exponent of zero is kept and
+// the rounding mode is left out if it is RoundHalfEven.
+func (g Gimel) MarshalText() ([]byte, error) {
+	if g.digits == nil || g.prec == nil {
+		return nil, &OpError{"marshal", ErrPrecision}
+	}
+	var b strings.Builder
+	b.WriteString(g.TextE())
+	if g.IsZero() {
+		b.WriteByte('e')
+		b.WriteString(g.exp.String())
+	}
+	b.WriteByte(':')
+	b.WriteString(g.prec.String())
+	if g.mode != RoundHalfEven {
+		b.WriteByte(':')
+		b.WriteString(g.mode.String())
+	}
+	return []byte(b.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+//
+// The text can be in the form written by MarshalText or a bare number like
+// 0.3 which takes its precision from the number of digits, as used by config
+// files and environment variables. If the text is invalid the error is a
+// *ParseError.
+func (g *Gimel) UnmarshalText(text []byte) error {
+	s := string(text)
+	parts := strings.SplitN(s, ":", 3)
+	if len(parts) == 1 {
+		a, err := Parse(s)
+		if err != nil {
+			return err
+		}
+		*g = a
+		return nil
+	}
+
+	// offset of the precision
+	off := len(parts[0]) + 1
+	prec, ok := new(big.Int).SetString(parts[1], 10)
+	if !ok || prec.Sign() != 1 || prec.Cmp(big.NewInt(maxPrec)) == 1 {
+		return newParseError(s, off, &tokenError{expected: "precision"})
+	}
+	mode := RoundHalfEven
+	if len(parts) == 3 {
+		off += len(parts[1]) + 1
+		if mode, ok = parseRoundingMode(parts[2]); !ok {
+			return newParseError(s, off, &tokenError{expected: "rounding mode"})
+		}
+	}
+
+	a, err := Parse(parts[0], WithPrecision(prec))
+	if err != nil {
+		if e, ok := err.(*ParseError); ok {
+			e.Input = s
+		}
+		return err
+	}
+	a.mode = mode
+	*g = a
+	return nil
+}
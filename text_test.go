@@ -0,0 +1,78 @@
+package gimel
+
+import (
+	"encoding/xml"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+)
+
+// identical returns true if both numbers have the same fields
+func identical(a, b Gimel) bool {
+	return a.neg == b.neg && a.digits.Cmp(b.digits) == 0 && a.exp.Cmp(b.exp) == 0 &&
+		a.prec.Cmp(b.prec) == 0 && a.p10p.Cmp(b.p10p) == 0 && a.mode == b.mode && a.form == b.form
+}
+
+func TestGimel_MarshalText(t *testing.T) {
+	for _, i := range []struct {
+		g Gimel
+		s string
+	}{
+		{gen(false, 123, 6), "1.23e6:5"},
+		{gen(true, 15, -3).Rounding(RoundDown), "-1.5e-3:5:RoundDown"},
+		{gen(true, 0, 3), "-0e3:5"},
+		{Inf(false, prec), "Infinity:5"},
+		{special(snan, true, big.NewInt(12), prec, Round05Up), "-sNaN12:5:Round05Up"},
+		{Pi, "3." + _PiDigits[1:] + "e0:100"},
+	} {
+		b, err := i.g.MarshalText()
+		assert.NoError(t, err)
+		assert.Equal(t, i.s, string(b))
+
+		// the round trip is exact
+		var a Gimel
+		assert.NoError(t, a.UnmarshalText(b))
+		assert.True(t, identical(i.g, a), i.s)
+	}
+
+	_, err := Gimel{}.MarshalText()
+	assert.Error(t, err)
+}
+
+func TestGimel_UnmarshalText(t *testing.T) {
+	var a Gimel
+	for s, e := range map[string]*ParseError{
+		"1.5x":         {"1.5x", 3, 'x', "end of input"},
+		"1.5:x":        {"1.5:x", 4, 'x', "precision"},
+		"1.5:0":        {"1.5:0", 4, '0', "precision"},
+		"1:50000000":   {"1:50000000", 2, '5', "precision"},
+		"1.5:5:Round":  {"1.5:5:Round", 6, 'R', "rounding mode"},
+		"1.5x:5":       {"1.5x:5", 3, 'x', "end of input"},
+		"1.5:5:Down:x": {"1.5:5:Down:x", 6, 'D', "rounding mode"},
+	} {
+		assert.Equal(t, e, a.UnmarshalText([]byte(s)), s)
+	}
+
+	// both the lossless form and a bare number are accepted
+	for s, g := range map[string]Gimel{
+		"0.3:5":         G(false, big.NewInt(30000), big.NewInt(-1), prec),
+		"0.3":           G(false, big.NewInt(3), big.NewInt(-1), big.NewInt(1)),
+		"-1.250e2":      G(true, big.NewInt(1250), big.NewInt(2), big.NewInt(4)),
+		"1.5:5:RoundUp": G(false, big.NewInt(15000), big.NewInt(0), prec).Rounding(RoundUp),
+	} {
+		assert.NoError(t, a.UnmarshalText([]byte(s)), s)
+		assert.True(t, identical(g, a), s)
+	}
+
+	// the text form is used by other encoders
+	var x struct {
+		XMLName xml.Name `xml:"x"`
+		A       Gimel    `xml:"a,attr"`
+	}
+	assert.NoError(t, xml.Unmarshal([]byte(`<x a="1.25e2:3:RoundUp"></x>`), &x))
+	assert.Equal(t, "1.25e2", x.A.String())
+	assert.Equal(t, RoundUp, x.A.Mode())
+	b, err := xml.Marshal(x)
+	assert.NoError(t, err)
+	assert.Equal(t, `<x a="1.25e2:3:RoundUp"></x>`, string(b))
+}
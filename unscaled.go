@@ -0,0 +1,65 @@
+package gimel
+
+import (
+	"errors"
+	"math/big"
+)
+
+var errUnscaledRange = errors.New("gimel: number is out of range for the unscaled width")
+
+// ToUnscaled returns g*10^scale as a big-endian two's complement integer
+//
+// This is the decimal format used by Avro, Parquet and Arrow where the scale is
+// part of the schema. The number is rounded to the scale using its rounding
+// mode. The result has exactly width bytes, or the fewest bytes needed if width
+// is 0, an error is returned if the number doesn't fit.
+func (g Gimel) ToUnscaled(scale int, width int) ([]byte, error) {
+	if err := checkPrec("unscaled", g); err != nil {
+		return nil, err
+	}
+	if !g.IsFinite() || width < 0 {
+		return nil, errUnscaledRange
+	}
+
+	// width bytes hold fewer than 8*width*log10(2)+1 digits so larger numbers are rejected before scaling
+	var limit int64
+	if width != 0 {
+		limit = int64(width)*8*30103/100000 + 1
+	}
+	n, ok := g.scaledDigits(int64(scale), limit)
+	if !ok {
+		return nil, errUnscaledRange
+	}
+	if g.neg {
+		n.Neg(n)
+	}
+
+	// the number of bits needed including the sign bit
+	var m big.Int
+	if n.Sign() == -1 {
+		m.Not(n)
+	} else {
+		m.Set(n)
+	}
+	k := (m.BitLen() + 8) / 8
+	if width != 0 {
+		if k > width {
+			return nil, errUnscaledRange
+		}
+		k = width
+	}
+	if n.Sign() == -1 {
+		n.Add(n, new(big.Int).Lsh(oneValue, uint(8*k)))
+	}
+	return n.FillBytes(make([]byte, k)), nil
+}
+
+// FromUnscaled returns the Gimel number from a big-endian two's complement integer and a scale
+// the value is b*10^-scale and the precision is the number of digits in b
+func FromUnscaled(b []byte, scale int) Gimel {
+	n := new(big.Int).SetBytes(b)
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		n.Sub(n, new(big.Int).Lsh(oneValue, uint(8*len(b))))
+	}
+	return fromCoef(false, n, big.NewInt(int64(-scale)), nil)
+}
@@ -0,0 +1,64 @@
+package gimel
+
+import (
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGimel_ToUnscaled(t *testing.T) {
+	for _, i := range []struct {
+		s            string
+		scale, width int
+		b            string
+	}{
+		{"1.23", 2, 0, "7b"},
+		{"-1.23", 2, 0, "85"},
+		{"1.28", 2, 0, "0080"},
+		{"-1.28", 2, 0, "80"},
+		{"-1.29", 2, 0, "ff7f"},
+		{"0", 2, 0, "00"},
+		{"12345.6789", 4, 16, "000000000000000000000000075bcd15"},
+		{"-12345.6789", 4, 16, "fffffffffffffffffffffffff8a432eb"},
+		{"1200", -2, 0, "0c"},
+	} {
+		g, err := Parse(i.s)
+		assert.NoError(t, err)
+		b, err := g.ToUnscaled(i.scale, i.width)
+		assert.NoError(t, err)
+		assert.Equal(t, i.b, hex.EncodeToString(b), i.s)
+		assert.True(t, g.Eq(FromUnscaled(b, i.scale)), i.s)
+	}
+
+	// extra digits are rounded with the rounding mode
+	b, err := gen(false, 12345, 0).ToUnscaled(2, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "7b", hex.EncodeToString(b))
+	b, err = gen(false, 12345, 0).Rounding(RoundUp).ToUnscaled(2, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "7c", hex.EncodeToString(b))
+
+	// huge and tiny exponents are handled without scaling by the exponent
+	_, err = gen(false, 1, 30000000).ToUnscaled(0, 16)
+	assert.Error(t, err)
+	b, err = gen(false, 1, -30000000).ToUnscaled(2, 16)
+	assert.NoError(t, err)
+	assert.Equal(t, make([]byte, 16), b)
+
+	_, err = gen(false, 128, 2).ToUnscaled(0, 1)
+	assert.Error(t, err)
+	_, err = gen(true, 128, 2).ToUnscaled(0, 1)
+	assert.NoError(t, err)
+	_, err = Inf(false, prec).ToUnscaled(0, 0)
+	assert.Error(t, err)
+	_, err = Gimel{}.ToUnscaled(0, 0)
+	assert.Error(t, err)
+}
+
+func TestFromUnscaled(t *testing.T) {
+	assert.Equal(t, "-1.23e0", FromUnscaled([]byte{0x85}, 2).String())
+	assert.Equal(t, "1.28e0", FromUnscaled([]byte{0x00, 0x80}, 2).String())
+	assert.Equal(t, "1.2e3", FromUnscaled([]byte{0x0c}, -2).String())
+	assert.True(t, FromUnscaled(nil, 2).IsZero())
+	assert.Equal(t, "1.2345e-1", FromUnscaled([]byte{0x30, 0x39}, 5).String())
+}
@@ -0,0 +1,60 @@
+package gimel
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MarshalYAML implements yaml.Marshaler
+//
+// The number is written as an exact plain float scalar in the TextE form so it
+// isn't quoted. Infinities and NaN use the YAML forms .inf, -.inf and .nan,
+// signalling NaNs and NaNs with a sign or payload are written as strings.
+func (g Gimel) MarshalYAML() (interface{}, error) {
+	if err := checkPrec("marshal", g); err != nil {
+		return nil, err
+	}
+	n := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!float"}
+	switch {
+	case g.form == finite:
+		n.Value = g.TextE()
+	case g.IsInf() && g.neg:
+		n.Value = "-.inf"
+	case g.IsInf():
+		n.Value = ".inf"
+	case g.form == qnan && !g.neg && g.digits.Sign() == 0:
+		n.Value = ".nan"
+	default:
+		n.Tag, n.Value = "!!str", g.TextE()
+	}
+	return n, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler
+//
+// The scalar is parsed from its text so it is never rounded to a float64,
+// quoted strings and the YAML forms of infinity and NaN are also accepted, null
+// leaves the number unchanged. The precision of g is kept if it has one,
+// otherwise all the digits are kept.
+func (g *Gimel) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind != yaml.ScalarNode {
+		return fmt.Errorf("gimel: cannot unmarshal YAML node at line %d into a number", value.Line)
+	}
+	if value.ShortTag() == "!!null" {
+		return nil
+	}
+	s := value.Value
+	switch strings.ToLower(strings.TrimLeft(s, "+-")) {
+	case ".inf", ".nan":
+		// remove the dot for Parse
+		s = strings.Replace(s, ".", "", 1)
+	}
+	a, err := Parse(s, WithPrecision(g.prec))
+	if err != nil {
+		return err
+	}
+	*g = a
+	return nil
+}
@@ -0,0 +1,70 @@
+package gimel
+
+import (
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"math/big"
+	"testing"
+)
+
+func TestGimel_MarshalYAML(t *testing.T) {
+	for _, i := range []struct {
+		g Gimel
+		s string
+	}{
+		{gen(false, 12345, 2), "limit: 1.2345e2\n"},
+		{gen(true, 5, -3), "limit: -5e-3\n"},
+		{Inf(false, prec), "limit: .inf\n"},
+		{Inf(true, prec), "limit: -.inf\n"},
+		{NaN(prec), "limit: .nan\n"},
+		{SNaN(prec), "limit: sNaN\n"},
+	} {
+		b, err := yaml.Marshal(map[string]Gimel{"limit": i.g})
+		assert.NoError(t, err)
+		assert.Equal(t, i.s, string(b))
+	}
+
+	_, err := yaml.Marshal(Gimel{})
+	assert.Error(t, err)
+}
+
+func TestGimel_UnmarshalYAML(t *testing.T) {
+	var c struct {
+		Limit Gimel
+	}
+	assert.NoError(t, yaml.Unmarshal([]byte("limit: 0.30000000000000000001"), &c))
+	assert.Equal(t, "3.0000000000000000001e-1", c.Limit.String())
+	assert.Equal(t, big.NewInt(20), c.Limit.prec)
+
+	// the existing precision is kept
+	c.Limit = gen(false, 1, 0)
+	assert.NoError(t, yaml.Unmarshal([]byte("limit: '0.30000000000000000001'"), &c))
+	assert.True(t, c.Limit.Eq(gen(false, 3, -1)))
+
+	// null leaves the number unchanged
+	assert.NoError(t, yaml.Unmarshal([]byte("limit: null"), &c))
+	assert.True(t, c.Limit.Eq(gen(false, 3, -1)))
+
+	for _, i := range []struct {
+		s string
+		g Gimel
+	}{
+		{"limit: .inf", Inf(false, prec)},
+		{"limit: -.Inf", Inf(true, prec)},
+		{"limit: .NaN", NaN(prec)},
+		{"limit: -12e3", gen(true, 12, 4)},
+	} {
+		assert.NoError(t, yaml.Unmarshal([]byte(i.s), &c))
+		assert.True(t, identical(i.g, c.Limit) || i.g.IsNaN() && c.Limit.IsNaN(), i.s)
+	}
+
+	assert.Error(t, yaml.Unmarshal([]byte("limit: abc"), &c))
+	assert.Error(t, yaml.Unmarshal([]byte("limit: [1]"), &c))
+
+	// round trip through Marshal
+	b, err := yaml.Marshal(map[string]Gimel{"limit": gen(true, 12345, -7)})
+	assert.NoError(t, err)
+	var a struct{ Limit Gimel }
+	assert.NoError(t, yaml.Unmarshal(b, &a))
+	assert.True(t, a.Limit.Eq(gen(true, 12345, -7)))
+}